@@ -0,0 +1,111 @@
+package go_eth_multicall
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// ErrNoRPCClient is returned by the batch strategy when the caller has no JSON-RPC connection to batch over
+var ErrNoRPCClient = errors.New("multicall: the batch strategy needs RPCClient")
+
+// Strategy is how an EthMultiCaller sends a set of calls to the node
+type Strategy int
+
+const (
+	// StrategyAggregate sends the calls in a single tryAggregate to the multicall contract
+	StrategyAggregate Strategy = iota
+	// StrategyBatch sends each call as its own eth_call inside a single JSON-RPC batch, for chains without a
+	// multicall contract. Calls to the multicall contract itself, like GetBlockNumberCall, still need it deployed.
+	StrategyBatch
+)
+
+func (strategy Strategy) String() string {
+	switch strategy {
+	case StrategyAggregate:
+		return "aggregate"
+	case StrategyBatch:
+		return "batch"
+	}
+
+	return "unknown"
+}
+
+// batchCall sends each call as its own eth_call in a single JSON-RPC batch executed at the given block, which is
+// either a block number, nil meaning the latest block, or a block hash. A reverting call is reported like
+// tryAggregate does, unsuccessful with the revert data, and a call failing otherwise, e.g. out of gas, is
+// unsuccessful with the error as reason. Only failures of the endpoint fail the whole batch.
+func (caller *EthMultiCaller) batchCall(ctx context.Context, calls []Call, blockNumber *big.Int, blockHash *common.Hash) ([]CallResponse, error) {
+	rpcClient := caller.rpcClient(ctx)
+	if rpcClient == nil {
+		return nil, ErrNoRPCClient
+	}
+
+	block := blockArg(blockNumber, blockHash)
+
+	results := make([]hexutil.Bytes, len(calls))
+	batch := make([]rpc.BatchElem, len(calls))
+	for i, call := range calls {
+		batch[i] = rpc.BatchElem{
+			Method: "eth_call",
+			Args:   []interface{}{map[string]interface{}{"to": call.Target, "data": hexutil.Bytes(call.CallData)}, block},
+			Result: &results[i],
+		}
+	}
+
+	responses := make([]CallResponse, len(calls))
+	err := caller.withRetry(ctx, func() error {
+		if err := caller.limiter(ctx).Wait(ctx, "eth_call", len(batch)); err != nil {
+			return err
+		}
+		for i := range batch {
+			batch[i].Error = nil
+		}
+		if err := rpcClient.BatchCallContext(ctx, batch); err != nil {
+			return err
+		}
+
+		for i := range batch {
+			if batch[i].Error == nil {
+				responses[i] = CallResponse{Success: true, ReturnData: results[i]}
+				continue
+			}
+			switch {
+			case endpointFault(batch[i].Error):
+				return batch[i].Error
+			case ClassifyError(batch[i].Error) == ErrorReverted:
+				responses[i] = CallResponse{Success: false, ReturnData: revertData(batch[i].Error)}
+			default:
+				responses[i] = CallResponse{Success: false, Error: batch[i].Error.Error()}
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return responses, nil
+}
+
+// revertData returns the data a reverting eth_call failed with, if the node sent it
+func revertData(err error) []byte {
+	var dataErr rpc.DataError
+	if !errors.As(err, &dataErr) {
+		return nil
+	}
+
+	if data, ok := dataErr.ErrorData().(string); ok {
+		decoded, err := hexutil.Decode(data)
+		if err == nil {
+			return decoded
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package go_eth_multicall
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	MultiCall2 "github.com/truongpx396/go-eth-multicall/contracts/MultiCall"
+)
+
+func TestBatchCallErrors(t *testing.T) {
+	tests := []struct {
+		err error
+		// fails is whether the error fails the whole batch rather than the call
+		fails bool
+	}{
+		{err: errors.New("out of gas")},
+		{err: errors.New("gas required exceeds allowance (50000000)")},
+		{err: errors.New("response too large")},
+		{err: errors.New("too many requests"), fails: true},
+		{err: errors.New("header not found"), fails: true},
+	}
+
+	for _, test := range tests {
+		node, caller := newFakeNode(t)
+		caller.Strategy = StrategyBatch
+
+		calls := bisectCalls(3)
+		node.fail = func(attempt int, aggregated []MultiCall2.Multicall2Call) error {
+			if bytes.Equal(aggregated[0].CallData, calls[1].CallData) {
+				return test.err
+			}
+			return nil
+		}
+
+		responses, err := caller.ExecuteContext(context.Background(), calls, nil)
+		if test.fails {
+			if err == nil {
+				t.Errorf("%v: the batch did not fail", test.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%v: %v", test.err, err)
+		}
+
+		for i, response := range responses {
+			failed := i == 1
+			if response.Success == failed || (failed && response.Error != test.err.Error()) || (!failed && !bytes.Equal(response.ReturnData, calls[i].CallData)) {
+				t.Errorf("%v: call %d: got %+v", test.err, i, response)
+			}
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package go_eth_multicall
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// poisoned reports whether a failed aggregate may be the fault of some of its calls. tryAggregate does not revert
+// on failing calls, so a revert means a call took all the gas, and calls returning too much data can push the
+// response over the limit of the node. Any other failure would fail every part of the aggregate the same way.
+func poisoned(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if ClassifyError(err) == ErrorReverted {
+		return true
+	}
+
+	message := strings.ToLower(err.Error())
+	for _, fragment := range poisonMessages {
+		if strings.Contains(message, fragment) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// poisonMessages are the fragments of the errors nodes return for aggregates running out of gas or answering with
+// more data than they allow
+var poisonMessages = []string{
+	"out of gas",
+	"gas required exceeds",
+	"response size",
+	"response too large",
+	"too large",
+	"too big",
+}
+
+// bisectAggregate recovers from an aggregate that failed with err as a whole. The calls are split in halves that
+// are executed on their own, halves failing the same way are split again, down to the calls that fail alone. Those
+// are marked unsuccessful with the error as reason, every other call gets its response.
+//
+// The first time both halves of a split fail, an empty aggregate probes whether the calls are at fault at all. When
+// it fails too, e.g. the multicall address is wrong, bisecting can not help and its error is returned.
+func (caller *EthMultiCaller) bisectAggregate(ctx context.Context, calls []Call, err error, aggregate func(calls []Call) ([]CallResponse, error)) ([]CallResponse, error) {
+	if len(calls) < 2 {
+		return nil, err
+	}
+	log.Warn("Multicall aggregate failed as a whole, bisecting", "calls", len(calls), "err", err)
+
+	probed := false
+	var probeErr error
+	probe := func() error {
+		if !probed {
+			probed = true
+			_, probeErr = aggregate(nil)
+		}
+		return probeErr
+	}
+
+	responses := make([]CallResponse, len(calls))
+	if fatal := bisect(ctx, calls, responses, err, aggregate, probe); fatal != nil {
+		return nil, fatal
+	}
+
+	return responses, nil
+}
+
+// bisect fills the responses of calls whose aggregate failed with err. It returns the error of a part that failed
+// in a way bisecting can not help with, or of the probe when both halves fail and the probe fails as well.
+func bisect(ctx context.Context, calls []Call, responses []CallResponse, err error, aggregate func(calls []Call) ([]CallResponse, error), probe func() error) error {
+	if len(calls) == 1 {
+		log.Warn("Multicall call poisons the aggregate", "name", calls[0].Name, "target", calls[0].Target, "err", err)
+		responses[0] = CallResponse{Success: false, Error: err.Error()}
+		return nil
+	}
+
+	half := len(calls) / 2
+	parts := [][2]int{{0, half}, {half, len(calls)}}
+	errs := make([]error, len(parts))
+
+	for i, part := range parts {
+		partCalls := calls[part[0]:part[1]]
+
+		partResponses, partErr := aggregate(partCalls)
+		if partErr == nil && len(partResponses) != len(partCalls) {
+			partErr = fmt.Errorf("multicall: got %d responses for %d calls", len(partResponses), len(partCalls))
+		}
+		if partErr == nil {
+			copy(responses[part[0]:part[1]], partResponses)
+			continue
+		}
+		if !poisoned(ctx, partErr) {
+			return partErr
+		}
+		errs[i] = partErr
+	}
+
+	if errs[0] != nil && errs[1] != nil {
+		if probeErr := probe(); probeErr != nil {
+			log.Warn("Multicall aggregate fails without calls, not bisecting further", "calls", len(calls), "err", probeErr)
+			return probeErr
+		}
+	}
+
+	for i, part := range parts {
+		if errs[i] == nil {
+			continue
+		}
+
+		if fatal := bisect(ctx, calls[part[0]:part[1]], responses[part[0]:part[1]], errs[i], aggregate, probe); fatal != nil {
+			return fatal
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,137 @@
+package go_eth_multicall
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func bisectCalls(n int) []Call {
+	calls := make([]Call, n)
+	for i := range calls {
+		calls[i] = Call{Name: string(rune('a' + i)), Target: common.HexToAddress("0x02"), CallData: []byte{byte(i)}}
+	}
+
+	return calls
+}
+
+// poisonedAggregate fails with err whenever the calls include one of the poisoners, and counts the aggregates
+func poisonedAggregate(err error, count *int, poisoners ...int) func(calls []Call) ([]CallResponse, error) {
+	return func(calls []Call) ([]CallResponse, error) {
+		*count++
+		responses := make([]CallResponse, len(calls))
+		for i, call := range calls {
+			for _, poisoner := range poisoners {
+				if int(call.CallData[0]) == poisoner {
+					return nil, err
+				}
+			}
+			responses[i] = CallResponse{Success: true, ReturnData: call.CallData}
+		}
+
+		return responses, nil
+	}
+}
+
+func TestPoisoned(t *testing.T) {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		ctx  context.Context
+		err  error
+		want bool
+	}{
+		{ctx: context.Background(), err: errors.New("execution reverted"), want: true},
+		{ctx: context.Background(), err: errors.New("out of gas"), want: true},
+		{ctx: context.Background(), err: errors.New("gas required exceeds allowance (50000000)"), want: true},
+		{ctx: context.Background(), err: errors.New("Response size is larger than 150MB limit"), want: true},
+		{ctx: context.Background(), err: errors.New("header not found"), want: false},
+		{ctx: context.Background(), err: errors.New("abi: attempting to unmarshall an empty string while arguments are expected"), want: false},
+		{ctx: context.Background(), err: ErrRateLimited, want: false},
+		{ctx: canceled, err: errors.New("execution reverted"), want: false},
+	}
+
+	for _, test := range tests {
+		if got := poisoned(test.ctx, test.err); got != test.want {
+			t.Errorf("%v: got %v, want %v", test.err, got, test.want)
+		}
+	}
+}
+
+func TestBisectAggregate(t *testing.T) {
+	outOfGas := errors.New("out of gas")
+	caller := &EthMultiCaller{}
+
+	tests := []struct {
+		name       string
+		calls      int
+		poisoners  []int
+		err        error
+		aggregates int
+		// global makes every aggregate fail, the empty ones included
+		global bool
+	}{
+		// 8 -> 4+4 -> 2+2 -> 1+1
+		{name: "one poisoner", calls: 8, poisoners: []int{5}, err: outOfGas, aggregates: 6},
+		// 8 -> 4+4 -> 2+2, both quarters of the first half fail, so the probe runs -> 1+1 -> 1+1
+		{name: "poisoners in both quarters", calls: 8, poisoners: []int{1, 2}, err: outOfGas, aggregates: 9},
+		// 2 -> 1+1, both halves fail, the probe runs
+		{name: "poisoners in both halves", calls: 2, poisoners: []int{0, 1}, err: outOfGas, aggregates: 3},
+		// Both halves fail and so does the probe, e.g. a wrong multicall address
+		{name: "every call fails", calls: 64, err: errors.New("execution reverted"), aggregates: 3, global: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			calls := bisectCalls(test.calls)
+			count := 0
+			aggregate := poisonedAggregate(test.err, &count, test.poisoners...)
+			if test.global {
+				aggregate = func(calls []Call) ([]CallResponse, error) {
+					count++
+					return nil, test.err
+				}
+			}
+			responses, err := caller.bisectAggregate(context.Background(), calls, test.err, aggregate)
+
+			if count != test.aggregates {
+				t.Errorf("ran %d aggregates, want %d", count, test.aggregates)
+			}
+			if test.global {
+				if err != test.err {
+					t.Fatalf("got %v, want %v", err, test.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for i, response := range responses {
+				poisoner := false
+				for _, index := range test.poisoners {
+					poisoner = poisoner || index == i
+				}
+				if poisoner != !response.Success || (poisoner && response.Error != test.err.Error()) {
+					t.Errorf("call %d: got %+v", i, response)
+				}
+			}
+		})
+	}
+}
+
+func TestBisectAggregateFatal(t *testing.T) {
+	outOfGas := errors.New("out of gas")
+	rateLimited := errors.New("429 Too Many Requests")
+
+	calls := bisectCalls(4)
+	_, err := (&EthMultiCaller{}).bisectAggregate(context.Background(), calls, outOfGas, func(calls []Call) ([]CallResponse, error) {
+		return nil, rateLimited
+	})
+	if err != rateLimited {
+		t.Fatalf("got %v, want the error bisecting can not help with", err)
+	}
+}
@@ -0,0 +1,207 @@
+package go_eth_multicall
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// ErrBeforeGenesis is returned when resolving a time earlier than the first block of the chain
+var ErrBeforeGenesis = errors.New("multicall: time is before the genesis block")
+
+// maxBlockTimes caps the headers remembered per chain. Past it every other header is forgotten, which keeps the
+// bounds spread over the whole chain.
+const maxBlockTimes = 4096
+
+type blockTime struct {
+	number    uint64
+	timestamp uint64
+}
+
+// chainBlockTimes remembers the timestamps of the headers fetched while searching a chain, sorted by number, so
+// later searches start from narrower bounds. Two adjacent blocks around a timestamp resolve it without a search.
+type chainBlockTimes struct {
+	mu     sync.Mutex
+	blocks []blockTime
+}
+
+// blockTimes holds the search caches by chain id, it is shared by every caller of the same chain
+var blockTimes = struct {
+	sync.Mutex
+	chains map[uint64]*chainBlockTimes
+}{chains: make(map[uint64]*chainBlockTimes)}
+
+func blockTimesOf(chainID uint64) *chainBlockTimes {
+	blockTimes.Lock()
+	defer blockTimes.Unlock()
+
+	chain, ok := blockTimes.chains[chainID]
+	if !ok {
+		chain = &chainBlockTimes{}
+		blockTimes.chains[chainID] = chain
+	}
+
+	return chain
+}
+
+// bounds returns the closest known blocks around a timestamp: the last one at or before it and the first one after it
+func (chain *chainBlockTimes) bounds(timestamp uint64) (below uint64, belowOk bool, above uint64, aboveOk bool) {
+	chain.mu.Lock()
+	defer chain.mu.Unlock()
+
+	// Timestamps grow with block numbers, so the blocks are sorted by timestamp as well
+	i := sort.Search(len(chain.blocks), func(i int) bool { return chain.blocks[i].timestamp > timestamp })
+	if i > 0 {
+		below, belowOk = chain.blocks[i-1].number, true
+	}
+	if i < len(chain.blocks) {
+		above, aboveOk = chain.blocks[i].number, true
+	}
+
+	return below, belowOk, above, aboveOk
+}
+
+func (chain *chainBlockTimes) remember(header *blockHeader) {
+	chain.mu.Lock()
+	defer chain.mu.Unlock()
+
+	block := blockTime{number: header.Number.Uint64(), timestamp: header.Time}
+	i := sort.Search(len(chain.blocks), func(i int) bool { return chain.blocks[i].number >= block.number })
+	if i < len(chain.blocks) && chain.blocks[i].number == block.number {
+		chain.blocks[i] = block
+		return
+	}
+
+	chain.blocks = append(chain.blocks, blockTime{})
+	copy(chain.blocks[i+1:], chain.blocks[i:])
+	chain.blocks[i] = block
+
+	if len(chain.blocks) > maxBlockTimes {
+		kept := chain.blocks[:0]
+		for j := 0; j < len(chain.blocks); j += 2 {
+			kept = append(kept, chain.blocks[j])
+		}
+		chain.blocks = kept
+	}
+}
+
+// BlockAtTime returns the header of the last block mined at or before the given time.
+//
+// The block is found by binary search over headers. The timestamps of the headers seen are cached per chain, so
+// repeated lookups, e.g. every midnight of a year, get cheaper as they go. Times past the current head resolve
+// to the head.
+func (caller *EthMultiCaller) BlockAtTime(ctx context.Context, at time.Time) (*types.Header, error) {
+	var header *blockHeader
+	err := caller.route(ctx, func(ctx context.Context) (err error) {
+		header, err = caller.blockAtTime(ctx, at)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return header.Header, nil
+}
+
+func (caller *EthMultiCaller) blockAtTime(ctx context.Context, at time.Time) (*blockHeader, error) {
+	chainID, err := caller.chainIDUint64(ctx)
+	if err != nil {
+		return nil, err
+	}
+	chain := blockTimesOf(chainID)
+
+	if at.Unix() < 0 {
+		return nil, ErrBeforeGenesis
+	}
+	timestamp := uint64(at.Unix())
+
+	// A timestamp between two adjacent known blocks was resolved by an earlier search
+	if below, belowOk, above, aboveOk := chain.bounds(timestamp); belowOk && aboveOk && above == below+1 {
+		return caller.headerByNumber(ctx, new(big.Int).SetUint64(below))
+	}
+
+	head, err := caller.headerByNumber(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	if head.Time <= timestamp {
+		return head, nil
+	}
+	chain.remember(head)
+
+	// Search in [low, high): low is at or before the timestamp, high is after it
+	low, lowOk, high, highOk := chain.bounds(timestamp)
+	if !highOk || high > head.Number.Uint64() {
+		high = head.Number.Uint64()
+	}
+	if !lowOk {
+		genesis, err := caller.headerByNumber(ctx, big.NewInt(0))
+		if err != nil {
+			return nil, err
+		}
+		chain.remember(genesis)
+		if genesis.Time > timestamp {
+			return nil, ErrBeforeGenesis
+		}
+		low = 0
+	}
+
+	headers := make(map[uint64]*blockHeader)
+	for high-low > 1 {
+		middle := low + (high-low)/2
+
+		header, err := caller.headerByNumber(ctx, new(big.Int).SetUint64(middle))
+		if err != nil {
+			return nil, err
+		}
+		chain.remember(header)
+		headers[middle] = header
+
+		if header.Time <= timestamp {
+			low = middle
+		} else {
+			high = middle
+		}
+	}
+
+	if header, ok := headers[low]; ok {
+		return header, nil
+	}
+	return caller.headerByNumber(ctx, new(big.Int).SetUint64(low))
+}
+
+// ExecuteAsOf performs the multicall at the last block mined at or before the given time. The returned ResultSet
+// identifies the block that was used.
+func (caller *EthMultiCaller) ExecuteAsOf(ctx context.Context, calls []Call, at time.Time) (ResultSet, error) {
+	ctx, retries := countRetries(ctx)
+
+	// Both steps run on the same endpoint, which knows the block it resolved
+	var set ResultSet
+	err := caller.route(ctx, func(ctx context.Context) error {
+		header, err := caller.blockAtTime(ctx, at)
+		if err != nil {
+			return err
+		}
+		ctx, err = caller.withMulticallAt(ctx, header.Number)
+		if err != nil {
+			return err
+		}
+
+		set = newResultSet(header, calls)
+		set.Assurance = AssuranceBlockHash
+		set.Responses, err = caller.executeAtHash(ctx, calls, set.BlockHash)
+		return err
+	})
+	if err != nil {
+		return ResultSet{}, err
+	}
+	set.Retries = int(atomic.LoadInt64(retries))
+
+	return set, nil
+}
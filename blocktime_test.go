@@ -0,0 +1,74 @@
+package go_eth_multicall
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func rememberBlock(chain *chainBlockTimes, number, timestamp uint64) {
+	chain.remember(&blockHeader{Header: &types.Header{Number: new(big.Int).SetUint64(number), Time: timestamp}})
+}
+
+func TestBlockTimesBounds(t *testing.T) {
+	chain := &chainBlockTimes{}
+	for _, number := range []uint64{50, 10, 30, 20, 40} {
+		rememberBlock(chain, number, 1000+number*12)
+	}
+
+	tests := []struct {
+		timestamp uint64
+		below     uint64
+		belowOk   bool
+		above     uint64
+		aboveOk   bool
+	}{
+		{timestamp: 1000, above: 10, aboveOk: true},
+		{timestamp: 1000 + 10*12, below: 10, belowOk: true, above: 20, aboveOk: true},
+		{timestamp: 1000 + 35*12, below: 30, belowOk: true, above: 40, aboveOk: true},
+		{timestamp: 1000 + 60*12, below: 50, belowOk: true},
+	}
+	for _, test := range tests {
+		below, belowOk, above, aboveOk := chain.bounds(test.timestamp)
+		if below != test.below || belowOk != test.belowOk || above != test.above || aboveOk != test.aboveOk {
+			t.Errorf("%d: got %d %v, %d %v", test.timestamp, below, belowOk, above, aboveOk)
+		}
+	}
+}
+
+func TestBlockTimesCapped(t *testing.T) {
+	chain := &chainBlockTimes{}
+	for number := uint64(0); number < 3*maxBlockTimes; number++ {
+		rememberBlock(chain, number, number)
+	}
+
+	if len(chain.blocks) > maxBlockTimes {
+		t.Fatalf("remembered %d blocks, want at most %d", len(chain.blocks), maxBlockTimes)
+	}
+	for i := 1; i < len(chain.blocks); i++ {
+		if chain.blocks[i].number <= chain.blocks[i-1].number {
+			t.Fatalf("blocks out of order at %d: %v", i, chain.blocks[i-1:i+1])
+		}
+	}
+	if _, belowOk, _, aboveOk := chain.bounds(maxBlockTimes); !belowOk || !aboveOk {
+		t.Fatal("the remembered blocks do not span the chain anymore")
+	}
+}
+
+func TestExecuteAsOfBeforeDeployment(t *testing.T) {
+	node, caller := newFakeNode(t)
+	caller.Chain = &Chain{ChainID: 1, Multicalls: map[string]MulticallDeployment{VariantMulticall3: {DeploymentBlock: 200}}}
+	caller.Variant = VariantMulticall3
+
+	_, err := caller.ExecuteAsOf(context.Background(), consistencyCalls(), time.Unix(int64(node.head.Time), 0))
+	if !errors.Is(err, ErrBeforeDeployment) {
+		t.Fatalf("got %v, want ErrBeforeDeployment", err)
+	}
+	if got := node.count("eth_call"); got != 0 {
+		t.Fatalf("got %d eth_calls before the deployment", got)
+	}
+}
@@ -0,0 +1,172 @@
+package go_eth_multicall
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type cacheKey struct {
+	ChainID   uint64
+	BlockHash common.Hash
+	Target    common.Address
+	CallData  string
+}
+
+func newCacheKey(chainID uint64, blockHash common.Hash, call Call) cacheKey {
+	return cacheKey{ChainID: chainID, BlockHash: blockHash, Target: call.Target, CallData: string(call.CallData)}
+}
+
+// CacheStats are the counters of a CallCache
+type CacheStats struct {
+	Hits    uint64
+	Misses  uint64
+	Entries int
+}
+
+// CallCache keeps the responses of the calls executed at the head block of each chain, so identical calls made
+// by different parts of a program within the same block only reach the node once. Entries are dropped as soon
+// as an execution observes that the head of their chain has moved on.
+//
+// A CallCache is safe for concurrent use and can be shared by several EthMultiCallers, including callers of
+// different chains.
+type CallCache struct {
+	mu      sync.Mutex
+	heads   map[uint64]common.Hash
+	entries map[cacheKey]CallResponse
+	hits    uint64
+	misses  uint64
+}
+
+func NewCallCache() *CallCache {
+	return &CallCache{
+		heads:   make(map[uint64]common.Hash),
+		entries: make(map[cacheKey]CallResponse),
+	}
+}
+
+// advance records the head of a chain, dropping the entries of the previous head
+func (cache *CallCache) advance(chainID uint64, head common.Hash) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	if current, ok := cache.heads[chainID]; ok && current == head {
+		return
+	}
+
+	cache.heads[chainID] = head
+	for key := range cache.entries {
+		if key.ChainID == chainID && key.BlockHash != head {
+			delete(cache.entries, key)
+		}
+	}
+}
+
+// isHead reports whether the block is the last head seen for the chain, only those blocks are cached
+func (cache *CallCache) isHead(chainID uint64, blockHash common.Hash) bool {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	return cache.heads[chainID] == blockHash
+}
+
+func (cache *CallCache) get(key cacheKey) (CallResponse, bool) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	response, ok := cache.entries[key]
+	if ok {
+		cache.hits++
+	} else {
+		cache.misses++
+	}
+
+	return response, ok
+}
+
+func (cache *CallCache) put(key cacheKey, response CallResponse) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	// The head may have moved on while the call was in flight
+	if cache.heads[key.ChainID] == key.BlockHash {
+		cache.entries[key] = response
+	}
+}
+
+// Stats returns the hit and miss counters since the cache was created, along with the number of cached responses
+func (cache *CallCache) Stats() CacheStats {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	return CacheStats{Hits: cache.hits, Misses: cache.misses, Entries: len(cache.entries)}
+}
+
+// EnableCache attaches a new CallCache to the caller and returns it
+func (caller *EthMultiCaller) EnableCache() *CallCache {
+	caller.Cache = NewCallCache()
+	return caller.Cache
+}
+
+// chainIDUint64 returns the chain id of the client, which is only fetched once
+func (caller *EthMultiCaller) chainIDUint64(ctx context.Context) (uint64, error) {
+	if chainID, ok := caller.chainID.Load().(uint64); ok {
+		return chainID, nil
+	}
+
+	if err := caller.limiter(ctx).Wait(ctx, "eth_chainId", 1); err != nil {
+		return 0, err
+	}
+	chainID, err := caller.client(ctx).ChainID(ctx)
+	if err != nil {
+		return 0, err
+	}
+	caller.chainID.Store(chainID.Uint64())
+
+	return chainID.Uint64(), nil
+}
+
+// executeCached serves what it can of the calls from the cache and executes the rest at the same block.
+// head tells whether the block was just fetched as the latest block of the chain.
+func (caller *EthMultiCaller) executeCached(ctx context.Context, calls []Call, blockHash common.Hash, head bool) ([]CallResponse, error) {
+	chainID, err := caller.chainIDUint64(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only executions at the head are cached, historical ones go straight to the node
+	if head {
+		caller.Cache.advance(chainID, blockHash)
+	} else if !caller.Cache.isHead(chainID, blockHash) {
+		return caller.tryAggregateAtHash(ctx, calls, blockHash)
+	}
+
+	responses := make([]CallResponse, len(calls))
+	var missing []Call
+	var missingIndexes []int
+	for i, call := range calls {
+		if response, ok := caller.Cache.get(newCacheKey(chainID, blockHash, call)); ok {
+			responses[i] = response
+			continue
+		}
+		missing = append(missing, call)
+		missingIndexes = append(missingIndexes, i)
+	}
+
+	if len(missing) == 0 {
+		return responses, nil
+	}
+
+	missingResponses, err := caller.tryAggregateAtHash(ctx, missing, blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, response := range missingResponses {
+		responses[missingIndexes[i]] = response
+		caller.Cache.put(newCacheKey(chainID, blockHash, missing[i]), response)
+	}
+
+	return responses, nil
+}
@@ -0,0 +1,150 @@
+// Package chainlink reads Chainlink price feeds through an EthMultiCaller and flags the unhealthy ones.
+package chainlink
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	multicall "github.com/truongpx396/go-eth-multicall"
+	"github.com/truongpx396/go-eth-multicall/contracts/AggregatorV3Interface"
+)
+
+var aggregatorAbi = multicall.MustParseABI(AggregatorV3Interface.AggregatorV3InterfaceABI)
+
+// DefaultHeartbeat is used for feeds without a heartbeat of their own, it matches the longest
+// heartbeat of the common mainnet feeds
+const DefaultHeartbeat = 24 * time.Hour
+
+// Options configures the health checks
+type Options struct {
+	// Heartbeat is the maximum age of an answer before the feed is considered stale, DefaultHeartbeat when zero
+	Heartbeat time.Duration
+	// Heartbeats overrides Heartbeat for individual feeds
+	Heartbeats map[common.Address]time.Duration
+}
+
+func (options Options) heartbeat(feed common.Address) time.Duration {
+	if heartbeat, ok := options.Heartbeats[feed]; ok {
+		return heartbeat
+	}
+	if options.Heartbeat > 0 {
+		return options.Heartbeat
+	}
+
+	return DefaultHeartbeat
+}
+
+// Feed is the latest round of a feed together with the result of the health checks
+type Feed struct {
+	Address     common.Address
+	Description string
+	Decimals    uint8
+
+	RoundID         *big.Int
+	Answer          *big.Int
+	StartedAt       time.Time
+	UpdatedAt       time.Time
+	AnsweredInRound *big.Int
+
+	// Price is Answer scaled down by Decimals
+	Price *big.Rat
+
+	// Stale is set when UpdatedAt is older than the heartbeat at the block the feed was read in
+	Stale bool
+	// IncompleteRound is set when AnsweredInRound is behind RoundID
+	IncompleteRound bool
+	// InvalidAnswer is set when Answer is zero or negative
+	InvalidAnswer bool
+
+	// Err is set when the feed could not be read, in which case the other fields are incomplete
+	Err error
+}
+
+// Healthy reports whether the feed was read and passed every check
+func (feed Feed) Healthy() bool {
+	return feed.Err == nil && !feed.Stale && !feed.IncompleteRound && !feed.InvalidAnswer
+}
+
+// Snapshot is the state of all requested feeds, read at a single block
+type Snapshot struct {
+	BlockNumber    uint64
+	BlockTimestamp time.Time
+	Feeds          []Feed
+}
+
+// ReadFeeds reads the latest round of every feed in a single multicall. Staleness is judged against the
+// timestamp of the block the multicall ran in rather than the local clock. An error is returned when the
+// block number or timestamp call failed.
+func ReadFeeds(caller *multicall.EthMultiCaller, feeds []common.Address, options Options) (Snapshot, error) {
+	const (
+		blockNumberName    = "blockNumber"
+		blockTimestampName = "blockTimestamp"
+	)
+
+	calls := []multicall.Call{
+		caller.GetBlockNumberCall(blockNumberName),
+		caller.GetCurrentBlockTimestampCall(blockTimestampName),
+	}
+	for _, feed := range feeds {
+		calls = append(calls,
+			multicall.NewCall(aggregatorAbi, feed, "latestRoundData"),
+			multicall.NewCall(aggregatorAbi, feed, "decimals"),
+			multicall.NewCall(aggregatorAbi, feed, "description"),
+		)
+	}
+
+	responses := caller.Execute(calls)
+	blockNumber, err := multicall.UnpackBlockNumber(responses, blockNumberName)
+	if err != nil {
+		return Snapshot{}, err
+	}
+	blockTimestamp, err := multicall.UnpackBlockNumber(responses, blockTimestampName)
+	if err != nil {
+		return Snapshot{}, err
+	}
+
+	snapshot := Snapshot{
+		BlockNumber:    blockNumber.Uint64(),
+		BlockTimestamp: time.Unix(blockTimestamp.Int64(), 0),
+		Feeds:          make([]Feed, len(feeds)),
+	}
+	for i, address := range feeds {
+		feed := &snapshot.Feeds[i]
+		feed.Address = address
+		if feed.Err = decodeFeed(feed, responses); feed.Err != nil {
+			continue
+		}
+
+		feed.Stale = snapshot.BlockTimestamp.Sub(feed.UpdatedAt) > options.heartbeat(address)
+		feed.IncompleteRound = feed.AnsweredInRound.Cmp(feed.RoundID) < 0
+		feed.InvalidAnswer = feed.Answer.Sign() <= 0
+	}
+
+	return snapshot, nil
+}
+
+func decodeFeed(feed *Feed, responses map[string]multicall.CallResponse) error {
+	round, err := multicall.Unpack(aggregatorAbi, responses, feed.Address, "latestRoundData")
+	if err != nil {
+		return err
+	}
+	decimals, err := multicall.Unpack(aggregatorAbi, responses, feed.Address, "decimals")
+	if err != nil {
+		return err
+	}
+	// Not every aggregator implements description, it is informational only
+	if description, err := multicall.Unpack(aggregatorAbi, responses, feed.Address, "description"); err == nil {
+		feed.Description = description[0].(string)
+	}
+
+	feed.Decimals = decimals[0].(uint8)
+	feed.RoundID = round[0].(*big.Int)
+	feed.Answer = round[1].(*big.Int)
+	feed.StartedAt = time.Unix(round[2].(*big.Int).Int64(), 0)
+	feed.UpdatedAt = time.Unix(round[3].(*big.Int).Int64(), 0)
+	feed.AnsweredInRound = round[4].(*big.Int)
+	feed.Price = new(big.Rat).SetFrac(feed.Answer, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(feed.Decimals)), nil))
+
+	return nil
+}
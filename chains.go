@@ -0,0 +1,288 @@
+package go_eth_multicall
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// The multicall contract variants a chain can have
+const (
+	// VariantCustom is the CustomMulticall2 of this module, which adds tryAggregateBalances to Multicall2
+	VariantCustom = "custom"
+	// VariantMulticall3 is the Multicall3 deployed at the same address on most chains
+	VariantMulticall3 = "multicall3"
+	// VariantMulticall2 is the MakerDAO Multicall2
+	VariantMulticall2 = "multicall2"
+)
+
+// variantPreference breaks the ties between variants deployed at the same block, other variants come last
+var variantPreference = []string{VariantCustom, VariantMulticall3, VariantMulticall2}
+
+// ErrBeforeDeployment is returned when executing at a block before the multicall contract was deployed
+var ErrBeforeDeployment = errors.New("multicall: block is before the deployment of the multicall contract")
+
+//go:embed chains.json
+var builtinChains []byte
+
+// MulticallDeployment is where and when a multicall contract was deployed on a chain
+type MulticallDeployment struct {
+	Address         common.Address `json:"address"`
+	DeploymentBlock uint64         `json:"deploymentBlock"`
+}
+
+// Chain describes a known chain and its multicall contracts by variant
+type Chain struct {
+	ChainID      uint64                         `json:"chainId"`
+	Name         string                         `json:"name"`
+	NativeSymbol string                         `json:"nativeSymbol"`
+	Multicalls   map[string]MulticallDeployment `json:"multicalls"`
+}
+
+// Multicall returns the newest multicall deployment of the chain and its variant
+func (chain Chain) Multicall() (string, MulticallDeployment, bool) {
+	return chain.MulticallAt(nil)
+}
+
+// MulticallAt returns the newest multicall deployment of the chain at the given block and its variant, that is the
+// last one deployed at or before it. A nil block number means the latest block.
+func (chain Chain) MulticallAt(blockNumber *big.Int) (string, MulticallDeployment, bool) {
+	found := ""
+	var newest MulticallDeployment
+	for variant, deployment := range chain.Multicalls {
+		if blockNumber != nil && (!blockNumber.IsUint64() || blockNumber.Uint64() < deployment.DeploymentBlock) {
+			continue
+		}
+		if found == "" || deployment.DeploymentBlock > newest.DeploymentBlock ||
+			(deployment.DeploymentBlock == newest.DeploymentBlock && preferVariant(variant, found)) {
+			found, newest = variant, deployment
+		}
+	}
+
+	return found, newest, found != ""
+}
+
+// preferVariant reports whether variant a comes before b in variantPreference, other variants by name after them
+func preferVariant(a, b string) bool {
+	rank := func(variant string) int {
+		for i, preferred := range variantPreference {
+			if variant == preferred {
+				return i
+			}
+		}
+		return len(variantPreference)
+	}
+
+	if rank(a) != rank(b) {
+		return rank(a) < rank(b)
+	}
+	return a < b
+}
+
+// ChainRegistry holds the known chains by chain id. It is safe for concurrent use.
+type ChainRegistry struct {
+	mu     sync.RWMutex
+	chains map[uint64]Chain
+}
+
+// Chains is the registry NewForChain looks chains up in. It starts with the chains built into the module and can
+// be extended with Register or Load.
+var Chains = NewChainRegistry()
+
+// NewChainRegistry returns a registry of the chains built into the module
+func NewChainRegistry() *ChainRegistry {
+	registry := &ChainRegistry{chains: make(map[uint64]Chain)}
+	if err := registry.Load(bytes.NewReader(builtinChains)); err != nil {
+		panic(err)
+	}
+
+	return registry
+}
+
+// Register adds a chain, or extends the chain with the same id: the name and native symbol are replaced when set
+// and the multicall deployments are merged by variant
+func (registry *ChainRegistry) Register(chain Chain) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	existing, ok := registry.chains[chain.ChainID]
+	if !ok {
+		existing = Chain{ChainID: chain.ChainID}
+	}
+	if chain.Name != "" {
+		existing.Name = chain.Name
+	}
+	if chain.NativeSymbol != "" {
+		existing.NativeSymbol = chain.NativeSymbol
+	}
+
+	multicalls := make(map[string]MulticallDeployment, len(existing.Multicalls)+len(chain.Multicalls))
+	for variant, deployment := range existing.Multicalls {
+		multicalls[variant] = deployment
+	}
+	for variant, deployment := range chain.Multicalls {
+		multicalls[variant] = deployment
+	}
+	existing.Multicalls = multicalls
+
+	registry.chains[chain.ChainID] = existing
+}
+
+// Load registers the chains of a JSON array in the format of chains.json
+func (registry *ChainRegistry) Load(reader io.Reader) error {
+	var chains []Chain
+	if err := json.NewDecoder(reader).Decode(&chains); err != nil {
+		return fmt.Errorf("multicall: invalid chain registry: %w", err)
+	}
+
+	for _, chain := range chains {
+		if chain.ChainID == 0 {
+			return errors.New("multicall: invalid chain registry: chain without chainId")
+		}
+		registry.Register(chain)
+	}
+
+	return nil
+}
+
+// LoadFile registers the chains of a JSON file in the format of chains.json
+func (registry *ChainRegistry) LoadFile(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return registry.Load(file)
+}
+
+// Lookup returns the chain with the given id
+func (registry *ChainRegistry) Lookup(chainID uint64) (Chain, bool) {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+
+	chain, ok := registry.chains[chainID]
+	return chain, ok
+}
+
+// List returns the known chains ordered by chain id
+func (registry *ChainRegistry) List() []Chain {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+
+	chains := make([]Chain, 0, len(registry.chains))
+	for _, chain := range registry.chains {
+		chains = append(chains, chain)
+	}
+	sort.Slice(chains, func(i, j int) bool { return chains[i].ChainID < chains[j].ChainID })
+
+	return chains
+}
+
+// NewForChain connects to rpcURL, detects the chain and picks its multicall contract from Chains. Chains without a
+// known multicall contract get the batch strategy.
+func NewForChain(rpcURL string) EthMultiCaller {
+	rpcClient, err := rpc.Dial(rpcURL)
+	if err != nil {
+		panic(err)
+	}
+
+	caller := newCaller(rpcClient, "")
+	chainID, err := caller.chainIDUint64(context.Background())
+	if err != nil {
+		panic(err)
+	}
+
+	chain, ok := Chains.Lookup(chainID)
+	if !ok {
+		caller.Strategy = StrategyBatch
+		return caller
+	}
+	caller.Chain = &chain
+
+	variant, deployment, ok := chain.Multicall()
+	if !ok {
+		caller.Strategy = StrategyBatch
+		return caller
+	}
+	caller.Variant = variant
+	caller.ContractAddress = deployment.Address
+
+	return caller
+}
+
+// multicallKey is the context key of the multicall contract an execution at a past block runs through
+type multicallKey struct{}
+
+// multicallAt returns the address of the multicall contract to execute at the given block with. When the caller
+// knows the deployments of its chain, that is the newest one at the block, and blocks before the first deployment
+// fail with ErrBeforeDeployment.
+func (caller *EthMultiCaller) multicallAt(blockNumber *big.Int) (common.Address, error) {
+	if blockNumber == nil || blockNumber.Sign() < 0 || caller.Chain == nil || caller.Variant == "" || caller.Strategy == StrategyBatch {
+		return caller.ContractAddress, nil
+	}
+
+	_, deployment, ok := caller.Chain.MulticallAt(blockNumber)
+	if !ok {
+		first := uint64(0)
+		for _, deployment := range caller.Chain.Multicalls {
+			if first == 0 || deployment.DeploymentBlock < first {
+				first = deployment.DeploymentBlock
+			}
+		}
+		return common.Address{}, fmt.Errorf("%w: block %d, deployed at %d", ErrBeforeDeployment, blockNumber.Uint64(), first)
+	}
+
+	return deployment.Address, nil
+}
+
+// withMulticallAt returns a context routing the execution at the given block through the multicall contract
+// deployed at it, see multicallAt
+func (caller *EthMultiCaller) withMulticallAt(ctx context.Context, blockNumber *big.Int) (context.Context, error) {
+	address, err := caller.multicallAt(blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	if address == caller.ContractAddress {
+		return ctx, nil
+	}
+
+	return context.WithValue(ctx, multicallKey{}, address), nil
+}
+
+// multicall returns the address of the multicall contract the execution runs through
+func (caller *EthMultiCaller) multicall(ctx context.Context) common.Address {
+	if address, ok := ctx.Value(multicallKey{}).(common.Address); ok {
+		return address
+	}
+
+	return caller.ContractAddress
+}
+
+// retarget points the calls to ContractAddress, like GetBlockNumberCall, at the given multicall contract instead
+func (caller *EthMultiCaller) retarget(calls []Call, multicall common.Address) []Call {
+	if multicall == caller.ContractAddress {
+		return calls
+	}
+
+	retargeted := make([]Call, len(calls))
+	for i, call := range calls {
+		if call.Target == caller.ContractAddress {
+			call.Target = multicall
+		}
+		retargeted[i] = call
+	}
+
+	return retargeted
+}
@@ -0,0 +1,86 @@
+package go_eth_multicall
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	MultiCall2 "github.com/truongpx396/go-eth-multicall/contracts/MultiCall"
+)
+
+func TestChainMulticallAt(t *testing.T) {
+	mainnet, ok := Chains.Lookup(1)
+	if !ok {
+		t.Fatal("mainnet is not registered")
+	}
+
+	tests := []struct {
+		block   *big.Int
+		variant string
+	}{
+		{block: nil, variant: VariantMulticall3},
+		{block: big.NewInt(12336032)},
+		{block: big.NewInt(12336033), variant: VariantMulticall2},
+		{block: big.NewInt(14353600), variant: VariantMulticall2},
+		{block: big.NewInt(14353601), variant: VariantMulticall3},
+		{block: big.NewInt(20000000), variant: VariantMulticall3},
+	}
+	for _, test := range tests {
+		variant, deployment, ok := mainnet.MulticallAt(test.block)
+		if variant != test.variant || ok != (test.variant != "") {
+			t.Errorf("block %v: got %q %v, want %q", test.block, variant, ok, test.variant)
+		}
+		if ok && deployment != mainnet.Multicalls[test.variant] {
+			t.Errorf("block %v: got deployment %v", test.block, deployment)
+		}
+	}
+
+	// Variants deployed at the same block are picked in order of preference
+	chain := Chain{Multicalls: map[string]MulticallDeployment{
+		VariantMulticall2: {DeploymentBlock: 10},
+		VariantMulticall3: {DeploymentBlock: 10},
+		"other":           {DeploymentBlock: 10},
+	}}
+	if variant, _, _ := chain.MulticallAt(nil); variant != VariantMulticall3 {
+		t.Errorf("got %q for a tie, want %q", variant, VariantMulticall3)
+	}
+}
+
+func TestExecuteWithBlockOlderMulticall(t *testing.T) {
+	node, caller := newFakeNode(t)
+	newer := common.HexToAddress("0x03")
+	caller.Chain = &Chain{ChainID: 1, Multicalls: map[string]MulticallDeployment{
+		VariantMulticall2: {Address: common.HexToAddress(fakeMulticallAddress), DeploymentBlock: 50},
+		VariantMulticall3: {Address: newer, DeploymentBlock: node.head.Number.Uint64() + 1},
+	}}
+	caller.Variant = VariantMulticall3
+	caller.ContractAddress = newer
+
+	var targets []common.Address
+	node.answer = func(attempt int, call MultiCall2.Multicall2Call) (fakeResult, bool) {
+		targets = append(targets, call.Target)
+		return fakeResult{}, false
+	}
+
+	calls := append(consistencyCalls(), caller.GetBlockNumberCall("block"))
+	set, err := caller.ExecuteWithBlock(context.Background(), calls, node.head.Number)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if block := set.Responses[len(calls)-1]; !block.Success || new(big.Int).SetBytes(block.ReturnData).Cmp(node.head.Number) != 0 {
+		t.Fatalf("got %+v for the block number", block)
+	}
+	for _, target := range targets {
+		if target == newer {
+			t.Fatal("a call went to the multicall contract deployed after the block")
+		}
+	}
+
+	// Before the first deployment there is no multicall contract to go through
+	caller.Chain.Multicalls[VariantMulticall2] = MulticallDeployment{Address: common.HexToAddress(fakeMulticallAddress), DeploymentBlock: 101}
+	if _, err := caller.ExecuteWithBlock(context.Background(), calls, node.head.Number); !errors.Is(err, ErrBeforeDeployment) {
+		t.Fatalf("got %v, want ErrBeforeDeployment", err)
+	}
+}
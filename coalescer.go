@@ -0,0 +1,199 @@
+package go_eth_multicall
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrCoalescerClosed is returned by the futures of calls submitted after the coalescer was closed
+var ErrCoalescerClosed = errors.New("multicall: coalescer closed")
+
+const (
+	DefaultCoalesceWindow       = 5 * time.Millisecond
+	DefaultCoalesceMaxBatchSize = 500
+)
+
+// CoalescerOptions controls when the calls collected by a Coalescer are sent
+type CoalescerOptions struct {
+	// Window is how long the first call of a batch waits for other calls to join it, DefaultCoalesceWindow when zero
+	Window time.Duration
+	// MaxBatchSize sends the batch early once it holds that many calls, DefaultCoalesceMaxBatchSize when zero
+	MaxBatchSize int
+}
+
+// Future is the pending response of a call submitted to a Coalescer
+type Future struct {
+	ctx      context.Context
+	call     Call
+	done     chan struct{}
+	response CallResponse
+	err      error
+}
+
+func (future *Future) resolve(response CallResponse, err error) {
+	future.response = response
+	future.err = err
+	close(future.done)
+}
+
+// Wait blocks until the response is available or the context the call was submitted with is done
+func (future *Future) Wait() (CallResponse, error) {
+	select {
+	case <-future.done:
+		return future.response, future.err
+	case <-future.ctx.Done():
+		return CallResponse{}, future.ctx.Err()
+	}
+}
+
+// Done is closed once the response is available
+func (future *Future) Done() <-chan struct{} {
+	return future.done
+}
+
+// Coalescer collects calls submitted by concurrent goroutines and sends them together in a single multicall,
+// so many small reads cost one eth_call instead of one each.
+//
+// A batch is sent once Window has passed since its first call or once it holds MaxBatchSize calls, whichever
+// comes first. Each waiter only depends on its own context: a cancelled waiter gets its context error right away
+// and its call is dropped from the batch if it was not sent yet, while the other waiters are unaffected. The
+// eth_call itself is only cancelled when every waiter of the batch is gone.
+type Coalescer struct {
+	caller  *EthMultiCaller
+	options CoalescerOptions
+
+	mu      sync.Mutex
+	pending []*Future
+	timer   *time.Timer
+	closed  bool
+}
+
+func NewCoalescer(caller *EthMultiCaller, options CoalescerOptions) *Coalescer {
+	if options.Window <= 0 {
+		options.Window = DefaultCoalesceWindow
+	}
+	if options.MaxBatchSize <= 0 {
+		options.MaxBatchSize = DefaultCoalesceMaxBatchSize
+	}
+
+	return &Coalescer{caller: caller, options: options}
+}
+
+// Submit queues a call for the next batch. The Name of the call is not used by the coalescer.
+func (coalescer *Coalescer) Submit(ctx context.Context, call Call) *Future {
+	future := &Future{ctx: ctx, call: call, done: make(chan struct{})}
+
+	coalescer.mu.Lock()
+	defer coalescer.mu.Unlock()
+
+	if coalescer.closed {
+		future.resolve(CallResponse{}, ErrCoalescerClosed)
+		return future
+	}
+
+	coalescer.pending = append(coalescer.pending, future)
+	if len(coalescer.pending) >= coalescer.options.MaxBatchSize {
+		coalescer.flushLocked()
+	} else if coalescer.timer == nil {
+		coalescer.timer = time.AfterFunc(coalescer.options.Window, coalescer.Flush)
+	}
+
+	return future
+}
+
+// Call submits a call and waits for its response
+func (coalescer *Coalescer) Call(ctx context.Context, call Call) (CallResponse, error) {
+	return coalescer.Submit(ctx, call).Wait()
+}
+
+// Flush sends the pending calls without waiting for the window to end
+func (coalescer *Coalescer) Flush() {
+	coalescer.mu.Lock()
+	defer coalescer.mu.Unlock()
+
+	coalescer.flushLocked()
+}
+
+// Close sends the pending calls and makes later submissions fail with ErrCoalescerClosed
+func (coalescer *Coalescer) Close() {
+	coalescer.mu.Lock()
+	defer coalescer.mu.Unlock()
+
+	coalescer.flushLocked()
+	coalescer.closed = true
+}
+
+func (coalescer *Coalescer) flushLocked() {
+	if coalescer.timer != nil {
+		coalescer.timer.Stop()
+		coalescer.timer = nil
+	}
+	if len(coalescer.pending) == 0 {
+		return
+	}
+
+	batch := coalescer.pending
+	coalescer.pending = nil
+
+	go coalescer.execute(batch)
+}
+
+func (coalescer *Coalescer) execute(batch []*Future) {
+	// Drop the calls whose waiters already gave up
+	waiting := batch[:0]
+	for _, future := range batch {
+		if err := future.ctx.Err(); err != nil {
+			future.resolve(CallResponse{}, err)
+			continue
+		}
+		waiting = append(waiting, future)
+	}
+	if len(waiting) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Cancel the eth_call once nobody is waiting for it anymore
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	remaining := len(waiting)
+	for _, future := range waiting {
+		wg.Add(1)
+		go func(future *Future) {
+			defer wg.Done()
+
+			select {
+			case <-future.ctx.Done():
+				mu.Lock()
+				remaining--
+				if remaining == 0 {
+					cancel()
+				}
+				mu.Unlock()
+			case <-ctx.Done():
+			}
+		}(future)
+	}
+
+	calls := make([]Call, len(waiting))
+	for i, future := range waiting {
+		calls[i] = future.call
+	}
+
+	responses, err := coalescer.caller.ExecuteContext(ctx, calls, nil)
+
+	cancel()
+	wg.Wait()
+
+	for i, future := range waiting {
+		if err != nil {
+			future.resolve(CallResponse{}, err)
+		} else {
+			future.resolve(responses[i], nil)
+		}
+	}
+}
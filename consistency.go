@@ -0,0 +1,179 @@
+package go_eth_multicall
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	MultiCall2 "github.com/truongpx396/go-eth-multicall/contracts/MultiCall"
+)
+
+// ErrInconsistent is returned in consistent mode when the chunks of an execution keep reporting different blocks
+var ErrInconsistent = errors.New("multicall: chunks executed at different blocks")
+
+// consistencyAttempts is how many times a consistent execution is run before giving up on inconsistent chunks
+const consistencyAttempts = 3
+
+// Assurance tells how sure it is that all the responses of a result set come from the same block
+type Assurance int
+
+const (
+	// AssuranceNone means the responses were not checked to come from one block
+	AssuranceNone Assurance = iota
+	// AssuranceMixed means some chunks reported another block than the one they were pinned to, the result set
+	// describes the pinned block
+	AssuranceMixed
+	// AssuranceBlockNumber means every chunk reported the same block number, but not the block hash, so a reorg
+	// between the chunks would go unnoticed
+	AssuranceBlockNumber
+	// AssuranceBlockHash means all responses come from the block with BlockHash: every chunk was pinned to it and
+	// reported its number and parent hash
+	AssuranceBlockHash
+)
+
+func (assurance Assurance) String() string {
+	switch assurance {
+	case AssuranceNone:
+		return "none"
+	case AssuranceMixed:
+		return "mixed"
+	case AssuranceBlockNumber:
+		return "block number"
+	case AssuranceBlockHash:
+		return "block hash"
+	}
+
+	return "unknown"
+}
+
+// blockAndAggregateConsistent runs the chunks of the calls pinned to the block with the given number, nil meaning
+// the latest block, and runs them again when a chunk reports another block than the one it was pinned to
+func (caller *EthMultiCaller) blockAndAggregateConsistent(ctx context.Context, calls []Call, blockNumber *big.Int, set *ResultSet) ([]CallResponse, error) {
+	for attempt := 1; ; attempt++ {
+		header, err := caller.headerByNumber(ctx, blockNumber)
+		if err != nil {
+			return nil, err
+		}
+
+		*set = newResultSet(header, set.Calls)
+		responses, err := caller.blockAndAggregateChunks(ctx, calls, header, set)
+		if err != nil || set.Assurance != AssuranceMixed {
+			return responses, err
+		}
+		if attempt == consistencyAttempts {
+			return nil, fmt.Errorf("%w: %d attempts pinned to block %d", ErrInconsistent, attempt, header.Number.Uint64())
+		}
+
+		log.Warn("Multicall chunks executed at different blocks, retrying", "block", header.Number, "hash", header.Hash(), "attempt", attempt)
+	}
+}
+
+// blockAndAggregateChunks runs the chunks of the calls through tryBlockAndAggregate pinned to the hash of the given
+// header, and records on set the assurance they all ran in it. Every chunk reports the number and parent hash of the
+// block it ran in, blockhash(block.number) being always zero inside the block itself.
+func (caller *EthMultiCaller) blockAndAggregateChunks(ctx context.Context, calls []Call, pinned *blockHeader, set *ResultSet) ([]CallResponse, error) {
+	chunks := chunkCalls(calls, caller.ChunkSize)
+
+	mixed := false
+	responses := make([]CallResponse, 0, len(calls))
+	for _, chunk := range chunks {
+		chunkResponses, err := caller.blockAndAggregateChunk(ctx, chunk, pinned, &mixed)
+		if err != nil {
+			return nil, err
+		}
+		responses = append(responses, chunkResponses...)
+	}
+
+	set.Assurance = AssuranceBlockHash
+	if mixed {
+		set.Assurance = AssuranceMixed
+	}
+
+	return responses, nil
+}
+
+// blockAndAggregateChunk runs a chunk pinned to the hash of the given header, bisecting it when some of its calls
+// poison the aggregate, and traces its failures. mixed is set when the chunk or one of its parts reports another
+// block than the pinned one.
+func (caller *EthMultiCaller) blockAndAggregateChunk(ctx context.Context, calls []Call, pinned *blockHeader, mixed *bool) ([]CallResponse, error) {
+	aggregate := func(calls []Call) ([]CallResponse, error) {
+		number, parentHash, responses, err := caller.blockAndAggregate(ctx, calls, pinned)
+		if err != nil {
+			return nil, err
+		}
+
+		// A node that does not honour the pinned hash runs the chunk at whatever block it has
+		if number != pinned.Number.Uint64() || parentHash != pinned.ParentHash {
+			*mixed = true
+		}
+		return responses, nil
+	}
+
+	responses, err := aggregate(calls)
+	if err != nil && poisoned(ctx, err) {
+		responses, err = caller.bisectAggregate(ctx, calls, err, aggregate)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	pinnedHash := pinned.Hash()
+	caller.traceFailures(ctx, calls, responses, blockArg(nil, &pinnedHash))
+
+	return responses, nil
+}
+
+// blockAndAggregate sends the calls in a single tryBlockAndAggregate pinned to the hash of the given header, and
+// returns the block number and parent hash the contract reported along with the responses. The parent hash is read
+// by a getLastBlockHash call appended to the aggregate, a zero hash when it failed.
+func (caller *EthMultiCaller) blockAndAggregate(ctx context.Context, calls []Call, pinned *blockHeader) (uint64, common.Hash, []CallResponse, error) {
+	multicall := caller.multicall(ctx)
+	var multiCalls = make([]MultiCall2.Multicall2Call, 0, len(calls)+1)
+	for _, call := range caller.retarget(calls, multicall) {
+		multiCalls = append(multiCalls, call.GetMultiCall())
+	}
+	parentHashCall := caller.GetLastBlockHashCall("parentHash")
+	parentHashCall.Target = multicall
+	multiCalls = append(multiCalls, parentHashCall.GetMultiCall())
+
+	callData, err := caller.Abi.Pack("tryBlockAndAggregate", false, multiCalls)
+	if err != nil {
+		return 0, common.Hash{}, nil, err
+	}
+
+	resp, err := caller.callContractAtHash(ctx, ethereum.CallMsg{To: &multicall, Data: callData}, pinned.Hash())
+	if err != nil {
+		return 0, common.Hash{}, nil, err
+	}
+
+	unpackedResp, err := caller.Abi.Unpack("tryBlockAndAggregate", resp)
+	if err != nil {
+		return 0, common.Hash{}, nil, err
+	}
+
+	number := unpackedResp[0].(*big.Int).Uint64()
+
+	var responses []CallResponse
+	a, err := json.Marshal(unpackedResp[2])
+	if err != nil {
+		return 0, common.Hash{}, nil, err
+	}
+	if err := json.Unmarshal(a, &responses); err != nil {
+		return 0, common.Hash{}, nil, err
+	}
+	if len(responses) != len(calls)+1 {
+		return 0, common.Hash{}, nil, fmt.Errorf("multicall: got %d responses for %d calls", len(responses), len(calls)+1)
+	}
+
+	var parentHash common.Hash
+	if last := responses[len(calls)]; last.Success && len(last.ReturnData) == common.HashLength {
+		parentHash = common.BytesToHash(last.ReturnData)
+	}
+
+	return number, parentHash, responses[:len(calls)], nil
+}
@@ -0,0 +1,137 @@
+package go_eth_multicall
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	MultiCall2 "github.com/truongpx396/go-eth-multicall/contracts/MultiCall"
+)
+
+func consistencyCalls() []Call {
+	calls := make([]Call, 4)
+	for i := range calls {
+		calls[i] = Call{Name: string(rune('a' + i)), Target: common.HexToAddress("0x02"), CallData: []byte{1, 2, 3, byte(i)}}
+	}
+
+	return calls
+}
+
+// answerParentHash makes the getLastBlockHash call of the chunks picked by wrong report another parent
+func answerParentHash(node *fakeNode, wrong func(attempt int) bool) {
+	selector := node.abi.Methods["getLastBlockHash"].ID
+	node.answer = func(attempt int, call MultiCall2.Multicall2Call) (fakeResult, bool) {
+		if !bytes.Equal(call.CallData, selector) || !wrong(attempt) {
+			return fakeResult{}, false
+		}
+
+		return fakeResult{Success: true, ReturnData: common.HexToHash("0xbad").Bytes()}, true
+	}
+}
+
+func TestExecuteWithBlockPinned(t *testing.T) {
+	node, caller := newFakeNode(t)
+	caller.ChunkSize = 2
+
+	calls := consistencyCalls()
+	set, err := caller.ExecuteWithBlock(context.Background(), calls, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if set.Assurance != AssuranceBlockHash {
+		t.Errorf("got assurance %s, want block hash", set.Assurance)
+	}
+	if set.BlockHash != node.headHash() || set.ParentHash != node.head.ParentHash || set.BlockNumber != 100 {
+		t.Errorf("got block %d %s, want the head", set.BlockNumber, set.BlockHash.Hex())
+	}
+	for i, response := range set.Responses {
+		if !bytes.Equal(response.ReturnData, calls[i].CallData) {
+			t.Errorf("call %d: got %x", i, response.ReturnData)
+		}
+	}
+	if got := node.count("eth_call"); got != 2 {
+		t.Errorf("got %d eth_calls, want one per chunk", got)
+	}
+}
+
+func TestExecuteWithBlockMixed(t *testing.T) {
+	node, caller := newFakeNode(t)
+	caller.ChunkSize = 2
+	// The second chunk runs on a node that ignores the pinned hash
+	answerParentHash(node, func(attempt int) bool { return attempt == 2 })
+
+	set, err := caller.ExecuteWithBlock(context.Background(), consistencyCalls(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if set.Assurance != AssuranceMixed {
+		t.Errorf("got assurance %s, want mixed", set.Assurance)
+	}
+}
+
+func TestExecuteWithBlockConsistentRetries(t *testing.T) {
+	node, caller := newFakeNode(t)
+	caller.ChunkSize = 2
+	caller.Consistent = true
+	// Only the first attempt has a mismatching chunk
+	answerParentHash(node, func(attempt int) bool { return attempt == 2 })
+
+	set, err := caller.ExecuteWithBlock(context.Background(), consistencyCalls(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if set.Assurance != AssuranceBlockHash {
+		t.Errorf("got assurance %s, want block hash", set.Assurance)
+	}
+	if got := node.count("eth_call"); got != 4 {
+		t.Errorf("got %d eth_calls, want two attempts of two chunks", got)
+	}
+}
+
+func TestExecuteWithBlockInconsistent(t *testing.T) {
+	node, caller := newFakeNode(t)
+	caller.ChunkSize = 2
+	caller.Consistent = true
+	// The second chunk of every attempt reports another parent
+	answerParentHash(node, func(attempt int) bool { return attempt%2 == 0 })
+
+	_, err := caller.ExecuteWithBlock(context.Background(), consistencyCalls(), nil)
+	if !errors.Is(err, ErrInconsistent) {
+		t.Fatalf("got %v, want ErrInconsistent", err)
+	}
+	if got := node.count("eth_call"); got != 2*consistencyAttempts {
+		t.Errorf("got %d eth_calls, want %d attempts of two chunks", got, consistencyAttempts)
+	}
+}
+
+func TestExecuteWithBlockBisects(t *testing.T) {
+	node, caller := newFakeNode(t)
+
+	calls := bisectCalls(4)
+	// The aggregates holding the third call run out of gas
+	node.fail = func(attempt int, aggregated []MultiCall2.Multicall2Call) error {
+		for _, call := range aggregated {
+			if bytes.Equal(call.CallData, calls[2].CallData) {
+				return errors.New("out of gas")
+			}
+		}
+		return nil
+	}
+
+	set, err := caller.ExecuteWithBlock(context.Background(), calls, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if set.Assurance != AssuranceBlockHash {
+		t.Errorf("got assurance %s, want block hash", set.Assurance)
+	}
+	for i, response := range set.Responses {
+		poisoner := i == 2
+		if response.Success == poisoner || (!poisoner && !bytes.Equal(response.ReturnData, calls[i].CallData)) {
+			t.Errorf("call %d: got %+v", i, response)
+		}
+	}
+}
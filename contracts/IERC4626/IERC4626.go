@@ -0,0 +1,1512 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package IERC4626
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+)
+
+// IERC4626MetaData contains all meta data concerning the IERC4626 contract.
+var IERC4626MetaData = &bind.MetaData{
+	ABI: "[{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"spender\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"}],\"name\":\"Approval\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"assets\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"shares\",\"type\":\"uint256\"}],\"name\":\"Deposit\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"from\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"}],\"name\":\"Transfer\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"receiver\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"assets\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"shares\",\"type\":\"uint256\"}],\"name\":\"Withdraw\",\"type\":\"event\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"spender\",\"type\":\"address\"}],\"name\":\"allowance\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"spender\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"approve\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"asset\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"assetTokenAddress\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"}],\"name\":\"balanceOf\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"shares\",\"type\":\"uint256\"}],\"name\":\"convertToAssets\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"assets\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"assets\",\"type\":\"uint256\"}],\"name\":\"convertToShares\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"shares\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"decimals\",\"outputs\":[{\"internalType\":\"uint8\",\"name\":\"\",\"type\":\"uint8\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"assets\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"receiver\",\"type\":\"address\"}],\"name\":\"deposit\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"shares\",\"type\":\"uint256\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"receiver\",\"type\":\"address\"}],\"name\":\"maxDeposit\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"maxAssets\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"receiver\",\"type\":\"address\"}],\"name\":\"maxMint\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"maxShares\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"}],\"name\":\"maxRedeem\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"maxShares\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"}],\"name\":\"maxWithdraw\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"maxAssets\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"shares\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"receiver\",\"type\":\"address\"}],\"name\":\"mint\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"assets\",\"type\":\"uint256\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"name\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"assets\",\"type\":\"uint256\"}],\"name\":\"previewDeposit\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"shares\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"shares\",\"type\":\"uint256\"}],\"name\":\"previewMint\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"assets\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"shares\",\"type\":\"uint256\"}],\"name\":\"previewRedeem\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"assets\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"assets\",\"type\":\"uint256\"}],\"name\":\"previewWithdraw\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"shares\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"shares\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"receiver\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"}],\"name\":\"redeem\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"assets\",\"type\":\"uint256\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"symbol\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"totalAssets\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"totalManagedAssets\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"totalSupply\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"transfer\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"from\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"transferFrom\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"assets\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"receiver\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"}],\"name\":\"withdraw\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"shares\",\"type\":\"uint256\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]",
+}
+
+// IERC4626ABI is the input ABI used to generate the binding from.
+// Deprecated: Use IERC4626MetaData.ABI instead.
+var IERC4626ABI = IERC4626MetaData.ABI
+
+// IERC4626 is an auto generated Go binding around an Ethereum contract.
+type IERC4626 struct {
+	IERC4626Caller     // Read-only binding to the contract
+	IERC4626Transactor // Write-only binding to the contract
+	IERC4626Filterer   // Log filterer for contract events
+}
+
+// IERC4626Caller is an auto generated read-only Go binding around an Ethereum contract.
+type IERC4626Caller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// IERC4626Transactor is an auto generated write-only Go binding around an Ethereum contract.
+type IERC4626Transactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// IERC4626Filterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type IERC4626Filterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// IERC4626Session is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type IERC4626Session struct {
+	Contract     *IERC4626         // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// IERC4626CallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type IERC4626CallerSession struct {
+	Contract *IERC4626Caller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts   // Call options to use throughout this session
+}
+
+// IERC4626TransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type IERC4626TransactorSession struct {
+	Contract     *IERC4626Transactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts   // Transaction auth options to use throughout this session
+}
+
+// IERC4626Raw is an auto generated low-level Go binding around an Ethereum contract.
+type IERC4626Raw struct {
+	Contract *IERC4626 // Generic contract binding to access the raw methods on
+}
+
+// IERC4626CallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type IERC4626CallerRaw struct {
+	Contract *IERC4626Caller // Generic read-only contract binding to access the raw methods on
+}
+
+// IERC4626TransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type IERC4626TransactorRaw struct {
+	Contract *IERC4626Transactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewIERC4626 creates a new instance of IERC4626, bound to a specific deployed contract.
+func NewIERC4626(address common.Address, backend bind.ContractBackend) (*IERC4626, error) {
+	contract, err := bindIERC4626(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &IERC4626{IERC4626Caller: IERC4626Caller{contract: contract}, IERC4626Transactor: IERC4626Transactor{contract: contract}, IERC4626Filterer: IERC4626Filterer{contract: contract}}, nil
+}
+
+// NewIERC4626Caller creates a new read-only instance of IERC4626, bound to a specific deployed contract.
+func NewIERC4626Caller(address common.Address, caller bind.ContractCaller) (*IERC4626Caller, error) {
+	contract, err := bindIERC4626(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &IERC4626Caller{contract: contract}, nil
+}
+
+// NewIERC4626Transactor creates a new write-only instance of IERC4626, bound to a specific deployed contract.
+func NewIERC4626Transactor(address common.Address, transactor bind.ContractTransactor) (*IERC4626Transactor, error) {
+	contract, err := bindIERC4626(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &IERC4626Transactor{contract: contract}, nil
+}
+
+// NewIERC4626Filterer creates a new log filterer instance of IERC4626, bound to a specific deployed contract.
+func NewIERC4626Filterer(address common.Address, filterer bind.ContractFilterer) (*IERC4626Filterer, error) {
+	contract, err := bindIERC4626(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &IERC4626Filterer{contract: contract}, nil
+}
+
+// bindIERC4626 binds a generic wrapper to an already deployed contract.
+func bindIERC4626(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := abi.JSON(strings.NewReader(IERC4626ABI))
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_IERC4626 *IERC4626Raw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _IERC4626.Contract.IERC4626Caller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_IERC4626 *IERC4626Raw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _IERC4626.Contract.IERC4626Transactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_IERC4626 *IERC4626Raw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _IERC4626.Contract.IERC4626Transactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_IERC4626 *IERC4626CallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _IERC4626.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_IERC4626 *IERC4626TransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _IERC4626.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_IERC4626 *IERC4626TransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _IERC4626.Contract.contract.Transact(opts, method, params...)
+}
+
+// Allowance is a free data retrieval call binding the contract method 0xdd62ed3e.
+//
+// Solidity: function allowance(address owner, address spender) view returns(uint256)
+func (_IERC4626 *IERC4626Caller) Allowance(opts *bind.CallOpts, owner common.Address, spender common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _IERC4626.contract.Call(opts, &out, "allowance", owner, spender)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// Allowance is a free data retrieval call binding the contract method 0xdd62ed3e.
+//
+// Solidity: function allowance(address owner, address spender) view returns(uint256)
+func (_IERC4626 *IERC4626Session) Allowance(owner common.Address, spender common.Address) (*big.Int, error) {
+	return _IERC4626.Contract.Allowance(&_IERC4626.CallOpts, owner, spender)
+}
+
+// Allowance is a free data retrieval call binding the contract method 0xdd62ed3e.
+//
+// Solidity: function allowance(address owner, address spender) view returns(uint256)
+func (_IERC4626 *IERC4626CallerSession) Allowance(owner common.Address, spender common.Address) (*big.Int, error) {
+	return _IERC4626.Contract.Allowance(&_IERC4626.CallOpts, owner, spender)
+}
+
+// Asset is a free data retrieval call binding the contract method 0x38d52e0f.
+//
+// Solidity: function asset() view returns(address assetTokenAddress)
+func (_IERC4626 *IERC4626Caller) Asset(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _IERC4626.contract.Call(opts, &out, "asset")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Asset is a free data retrieval call binding the contract method 0x38d52e0f.
+//
+// Solidity: function asset() view returns(address assetTokenAddress)
+func (_IERC4626 *IERC4626Session) Asset() (common.Address, error) {
+	return _IERC4626.Contract.Asset(&_IERC4626.CallOpts)
+}
+
+// Asset is a free data retrieval call binding the contract method 0x38d52e0f.
+//
+// Solidity: function asset() view returns(address assetTokenAddress)
+func (_IERC4626 *IERC4626CallerSession) Asset() (common.Address, error) {
+	return _IERC4626.Contract.Asset(&_IERC4626.CallOpts)
+}
+
+// BalanceOf is a free data retrieval call binding the contract method 0x70a08231.
+//
+// Solidity: function balanceOf(address account) view returns(uint256)
+func (_IERC4626 *IERC4626Caller) BalanceOf(opts *bind.CallOpts, account common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _IERC4626.contract.Call(opts, &out, "balanceOf", account)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// BalanceOf is a free data retrieval call binding the contract method 0x70a08231.
+//
+// Solidity: function balanceOf(address account) view returns(uint256)
+func (_IERC4626 *IERC4626Session) BalanceOf(account common.Address) (*big.Int, error) {
+	return _IERC4626.Contract.BalanceOf(&_IERC4626.CallOpts, account)
+}
+
+// BalanceOf is a free data retrieval call binding the contract method 0x70a08231.
+//
+// Solidity: function balanceOf(address account) view returns(uint256)
+func (_IERC4626 *IERC4626CallerSession) BalanceOf(account common.Address) (*big.Int, error) {
+	return _IERC4626.Contract.BalanceOf(&_IERC4626.CallOpts, account)
+}
+
+// ConvertToAssets is a free data retrieval call binding the contract method 0x07a2d13a.
+//
+// Solidity: function convertToAssets(uint256 shares) view returns(uint256 assets)
+func (_IERC4626 *IERC4626Caller) ConvertToAssets(opts *bind.CallOpts, shares *big.Int) (*big.Int, error) {
+	var out []interface{}
+	err := _IERC4626.contract.Call(opts, &out, "convertToAssets", shares)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// ConvertToAssets is a free data retrieval call binding the contract method 0x07a2d13a.
+//
+// Solidity: function convertToAssets(uint256 shares) view returns(uint256 assets)
+func (_IERC4626 *IERC4626Session) ConvertToAssets(shares *big.Int) (*big.Int, error) {
+	return _IERC4626.Contract.ConvertToAssets(&_IERC4626.CallOpts, shares)
+}
+
+// ConvertToAssets is a free data retrieval call binding the contract method 0x07a2d13a.
+//
+// Solidity: function convertToAssets(uint256 shares) view returns(uint256 assets)
+func (_IERC4626 *IERC4626CallerSession) ConvertToAssets(shares *big.Int) (*big.Int, error) {
+	return _IERC4626.Contract.ConvertToAssets(&_IERC4626.CallOpts, shares)
+}
+
+// ConvertToShares is a free data retrieval call binding the contract method 0xc6e6f592.
+//
+// Solidity: function convertToShares(uint256 assets) view returns(uint256 shares)
+func (_IERC4626 *IERC4626Caller) ConvertToShares(opts *bind.CallOpts, assets *big.Int) (*big.Int, error) {
+	var out []interface{}
+	err := _IERC4626.contract.Call(opts, &out, "convertToShares", assets)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// ConvertToShares is a free data retrieval call binding the contract method 0xc6e6f592.
+//
+// Solidity: function convertToShares(uint256 assets) view returns(uint256 shares)
+func (_IERC4626 *IERC4626Session) ConvertToShares(assets *big.Int) (*big.Int, error) {
+	return _IERC4626.Contract.ConvertToShares(&_IERC4626.CallOpts, assets)
+}
+
+// ConvertToShares is a free data retrieval call binding the contract method 0xc6e6f592.
+//
+// Solidity: function convertToShares(uint256 assets) view returns(uint256 shares)
+func (_IERC4626 *IERC4626CallerSession) ConvertToShares(assets *big.Int) (*big.Int, error) {
+	return _IERC4626.Contract.ConvertToShares(&_IERC4626.CallOpts, assets)
+}
+
+// Decimals is a free data retrieval call binding the contract method 0x313ce567.
+//
+// Solidity: function decimals() view returns(uint8)
+func (_IERC4626 *IERC4626Caller) Decimals(opts *bind.CallOpts) (uint8, error) {
+	var out []interface{}
+	err := _IERC4626.contract.Call(opts, &out, "decimals")
+
+	if err != nil {
+		return *new(uint8), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint8)).(*uint8)
+
+	return out0, err
+
+}
+
+// Decimals is a free data retrieval call binding the contract method 0x313ce567.
+//
+// Solidity: function decimals() view returns(uint8)
+func (_IERC4626 *IERC4626Session) Decimals() (uint8, error) {
+	return _IERC4626.Contract.Decimals(&_IERC4626.CallOpts)
+}
+
+// Decimals is a free data retrieval call binding the contract method 0x313ce567.
+//
+// Solidity: function decimals() view returns(uint8)
+func (_IERC4626 *IERC4626CallerSession) Decimals() (uint8, error) {
+	return _IERC4626.Contract.Decimals(&_IERC4626.CallOpts)
+}
+
+// MaxDeposit is a free data retrieval call binding the contract method 0x402d267d.
+//
+// Solidity: function maxDeposit(address receiver) view returns(uint256 maxAssets)
+func (_IERC4626 *IERC4626Caller) MaxDeposit(opts *bind.CallOpts, receiver common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _IERC4626.contract.Call(opts, &out, "maxDeposit", receiver)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// MaxDeposit is a free data retrieval call binding the contract method 0x402d267d.
+//
+// Solidity: function maxDeposit(address receiver) view returns(uint256 maxAssets)
+func (_IERC4626 *IERC4626Session) MaxDeposit(receiver common.Address) (*big.Int, error) {
+	return _IERC4626.Contract.MaxDeposit(&_IERC4626.CallOpts, receiver)
+}
+
+// MaxDeposit is a free data retrieval call binding the contract method 0x402d267d.
+//
+// Solidity: function maxDeposit(address receiver) view returns(uint256 maxAssets)
+func (_IERC4626 *IERC4626CallerSession) MaxDeposit(receiver common.Address) (*big.Int, error) {
+	return _IERC4626.Contract.MaxDeposit(&_IERC4626.CallOpts, receiver)
+}
+
+// MaxMint is a free data retrieval call binding the contract method 0xc63d75b6.
+//
+// Solidity: function maxMint(address receiver) view returns(uint256 maxShares)
+func (_IERC4626 *IERC4626Caller) MaxMint(opts *bind.CallOpts, receiver common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _IERC4626.contract.Call(opts, &out, "maxMint", receiver)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// MaxMint is a free data retrieval call binding the contract method 0xc63d75b6.
+//
+// Solidity: function maxMint(address receiver) view returns(uint256 maxShares)
+func (_IERC4626 *IERC4626Session) MaxMint(receiver common.Address) (*big.Int, error) {
+	return _IERC4626.Contract.MaxMint(&_IERC4626.CallOpts, receiver)
+}
+
+// MaxMint is a free data retrieval call binding the contract method 0xc63d75b6.
+//
+// Solidity: function maxMint(address receiver) view returns(uint256 maxShares)
+func (_IERC4626 *IERC4626CallerSession) MaxMint(receiver common.Address) (*big.Int, error) {
+	return _IERC4626.Contract.MaxMint(&_IERC4626.CallOpts, receiver)
+}
+
+// MaxRedeem is a free data retrieval call binding the contract method 0xd905777e.
+//
+// Solidity: function maxRedeem(address owner) view returns(uint256 maxShares)
+func (_IERC4626 *IERC4626Caller) MaxRedeem(opts *bind.CallOpts, owner common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _IERC4626.contract.Call(opts, &out, "maxRedeem", owner)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// MaxRedeem is a free data retrieval call binding the contract method 0xd905777e.
+//
+// Solidity: function maxRedeem(address owner) view returns(uint256 maxShares)
+func (_IERC4626 *IERC4626Session) MaxRedeem(owner common.Address) (*big.Int, error) {
+	return _IERC4626.Contract.MaxRedeem(&_IERC4626.CallOpts, owner)
+}
+
+// MaxRedeem is a free data retrieval call binding the contract method 0xd905777e.
+//
+// Solidity: function maxRedeem(address owner) view returns(uint256 maxShares)
+func (_IERC4626 *IERC4626CallerSession) MaxRedeem(owner common.Address) (*big.Int, error) {
+	return _IERC4626.Contract.MaxRedeem(&_IERC4626.CallOpts, owner)
+}
+
+// MaxWithdraw is a free data retrieval call binding the contract method 0xce96cb77.
+//
+// Solidity: function maxWithdraw(address owner) view returns(uint256 maxAssets)
+func (_IERC4626 *IERC4626Caller) MaxWithdraw(opts *bind.CallOpts, owner common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _IERC4626.contract.Call(opts, &out, "maxWithdraw", owner)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// MaxWithdraw is a free data retrieval call binding the contract method 0xce96cb77.
+//
+// Solidity: function maxWithdraw(address owner) view returns(uint256 maxAssets)
+func (_IERC4626 *IERC4626Session) MaxWithdraw(owner common.Address) (*big.Int, error) {
+	return _IERC4626.Contract.MaxWithdraw(&_IERC4626.CallOpts, owner)
+}
+
+// MaxWithdraw is a free data retrieval call binding the contract method 0xce96cb77.
+//
+// Solidity: function maxWithdraw(address owner) view returns(uint256 maxAssets)
+func (_IERC4626 *IERC4626CallerSession) MaxWithdraw(owner common.Address) (*big.Int, error) {
+	return _IERC4626.Contract.MaxWithdraw(&_IERC4626.CallOpts, owner)
+}
+
+// Name is a free data retrieval call binding the contract method 0x06fdde03.
+//
+// Solidity: function name() view returns(string)
+func (_IERC4626 *IERC4626Caller) Name(opts *bind.CallOpts) (string, error) {
+	var out []interface{}
+	err := _IERC4626.contract.Call(opts, &out, "name")
+
+	if err != nil {
+		return *new(string), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(string)).(*string)
+
+	return out0, err
+
+}
+
+// Name is a free data retrieval call binding the contract method 0x06fdde03.
+//
+// Solidity: function name() view returns(string)
+func (_IERC4626 *IERC4626Session) Name() (string, error) {
+	return _IERC4626.Contract.Name(&_IERC4626.CallOpts)
+}
+
+// Name is a free data retrieval call binding the contract method 0x06fdde03.
+//
+// Solidity: function name() view returns(string)
+func (_IERC4626 *IERC4626CallerSession) Name() (string, error) {
+	return _IERC4626.Contract.Name(&_IERC4626.CallOpts)
+}
+
+// PreviewDeposit is a free data retrieval call binding the contract method 0xef8b30f7.
+//
+// Solidity: function previewDeposit(uint256 assets) view returns(uint256 shares)
+func (_IERC4626 *IERC4626Caller) PreviewDeposit(opts *bind.CallOpts, assets *big.Int) (*big.Int, error) {
+	var out []interface{}
+	err := _IERC4626.contract.Call(opts, &out, "previewDeposit", assets)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// PreviewDeposit is a free data retrieval call binding the contract method 0xef8b30f7.
+//
+// Solidity: function previewDeposit(uint256 assets) view returns(uint256 shares)
+func (_IERC4626 *IERC4626Session) PreviewDeposit(assets *big.Int) (*big.Int, error) {
+	return _IERC4626.Contract.PreviewDeposit(&_IERC4626.CallOpts, assets)
+}
+
+// PreviewDeposit is a free data retrieval call binding the contract method 0xef8b30f7.
+//
+// Solidity: function previewDeposit(uint256 assets) view returns(uint256 shares)
+func (_IERC4626 *IERC4626CallerSession) PreviewDeposit(assets *big.Int) (*big.Int, error) {
+	return _IERC4626.Contract.PreviewDeposit(&_IERC4626.CallOpts, assets)
+}
+
+// PreviewMint is a free data retrieval call binding the contract method 0xb3d7f6b9.
+//
+// Solidity: function previewMint(uint256 shares) view returns(uint256 assets)
+func (_IERC4626 *IERC4626Caller) PreviewMint(opts *bind.CallOpts, shares *big.Int) (*big.Int, error) {
+	var out []interface{}
+	err := _IERC4626.contract.Call(opts, &out, "previewMint", shares)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// PreviewMint is a free data retrieval call binding the contract method 0xb3d7f6b9.
+//
+// Solidity: function previewMint(uint256 shares) view returns(uint256 assets)
+func (_IERC4626 *IERC4626Session) PreviewMint(shares *big.Int) (*big.Int, error) {
+	return _IERC4626.Contract.PreviewMint(&_IERC4626.CallOpts, shares)
+}
+
+// PreviewMint is a free data retrieval call binding the contract method 0xb3d7f6b9.
+//
+// Solidity: function previewMint(uint256 shares) view returns(uint256 assets)
+func (_IERC4626 *IERC4626CallerSession) PreviewMint(shares *big.Int) (*big.Int, error) {
+	return _IERC4626.Contract.PreviewMint(&_IERC4626.CallOpts, shares)
+}
+
+// PreviewRedeem is a free data retrieval call binding the contract method 0x4cdad506.
+//
+// Solidity: function previewRedeem(uint256 shares) view returns(uint256 assets)
+func (_IERC4626 *IERC4626Caller) PreviewRedeem(opts *bind.CallOpts, shares *big.Int) (*big.Int, error) {
+	var out []interface{}
+	err := _IERC4626.contract.Call(opts, &out, "previewRedeem", shares)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// PreviewRedeem is a free data retrieval call binding the contract method 0x4cdad506.
+//
+// Solidity: function previewRedeem(uint256 shares) view returns(uint256 assets)
+func (_IERC4626 *IERC4626Session) PreviewRedeem(shares *big.Int) (*big.Int, error) {
+	return _IERC4626.Contract.PreviewRedeem(&_IERC4626.CallOpts, shares)
+}
+
+// PreviewRedeem is a free data retrieval call binding the contract method 0x4cdad506.
+//
+// Solidity: function previewRedeem(uint256 shares) view returns(uint256 assets)
+func (_IERC4626 *IERC4626CallerSession) PreviewRedeem(shares *big.Int) (*big.Int, error) {
+	return _IERC4626.Contract.PreviewRedeem(&_IERC4626.CallOpts, shares)
+}
+
+// PreviewWithdraw is a free data retrieval call binding the contract method 0x0a28a477.
+//
+// Solidity: function previewWithdraw(uint256 assets) view returns(uint256 shares)
+func (_IERC4626 *IERC4626Caller) PreviewWithdraw(opts *bind.CallOpts, assets *big.Int) (*big.Int, error) {
+	var out []interface{}
+	err := _IERC4626.contract.Call(opts, &out, "previewWithdraw", assets)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// PreviewWithdraw is a free data retrieval call binding the contract method 0x0a28a477.
+//
+// Solidity: function previewWithdraw(uint256 assets) view returns(uint256 shares)
+func (_IERC4626 *IERC4626Session) PreviewWithdraw(assets *big.Int) (*big.Int, error) {
+	return _IERC4626.Contract.PreviewWithdraw(&_IERC4626.CallOpts, assets)
+}
+
+// PreviewWithdraw is a free data retrieval call binding the contract method 0x0a28a477.
+//
+// Solidity: function previewWithdraw(uint256 assets) view returns(uint256 shares)
+func (_IERC4626 *IERC4626CallerSession) PreviewWithdraw(assets *big.Int) (*big.Int, error) {
+	return _IERC4626.Contract.PreviewWithdraw(&_IERC4626.CallOpts, assets)
+}
+
+// Symbol is a free data retrieval call binding the contract method 0x95d89b41.
+//
+// Solidity: function symbol() view returns(string)
+func (_IERC4626 *IERC4626Caller) Symbol(opts *bind.CallOpts) (string, error) {
+	var out []interface{}
+	err := _IERC4626.contract.Call(opts, &out, "symbol")
+
+	if err != nil {
+		return *new(string), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(string)).(*string)
+
+	return out0, err
+
+}
+
+// Symbol is a free data retrieval call binding the contract method 0x95d89b41.
+//
+// Solidity: function symbol() view returns(string)
+func (_IERC4626 *IERC4626Session) Symbol() (string, error) {
+	return _IERC4626.Contract.Symbol(&_IERC4626.CallOpts)
+}
+
+// Symbol is a free data retrieval call binding the contract method 0x95d89b41.
+//
+// Solidity: function symbol() view returns(string)
+func (_IERC4626 *IERC4626CallerSession) Symbol() (string, error) {
+	return _IERC4626.Contract.Symbol(&_IERC4626.CallOpts)
+}
+
+// TotalAssets is a free data retrieval call binding the contract method 0x01e1d114.
+//
+// Solidity: function totalAssets() view returns(uint256 totalManagedAssets)
+func (_IERC4626 *IERC4626Caller) TotalAssets(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _IERC4626.contract.Call(opts, &out, "totalAssets")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// TotalAssets is a free data retrieval call binding the contract method 0x01e1d114.
+//
+// Solidity: function totalAssets() view returns(uint256 totalManagedAssets)
+func (_IERC4626 *IERC4626Session) TotalAssets() (*big.Int, error) {
+	return _IERC4626.Contract.TotalAssets(&_IERC4626.CallOpts)
+}
+
+// TotalAssets is a free data retrieval call binding the contract method 0x01e1d114.
+//
+// Solidity: function totalAssets() view returns(uint256 totalManagedAssets)
+func (_IERC4626 *IERC4626CallerSession) TotalAssets() (*big.Int, error) {
+	return _IERC4626.Contract.TotalAssets(&_IERC4626.CallOpts)
+}
+
+// TotalSupply is a free data retrieval call binding the contract method 0x18160ddd.
+//
+// Solidity: function totalSupply() view returns(uint256)
+func (_IERC4626 *IERC4626Caller) TotalSupply(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _IERC4626.contract.Call(opts, &out, "totalSupply")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// TotalSupply is a free data retrieval call binding the contract method 0x18160ddd.
+//
+// Solidity: function totalSupply() view returns(uint256)
+func (_IERC4626 *IERC4626Session) TotalSupply() (*big.Int, error) {
+	return _IERC4626.Contract.TotalSupply(&_IERC4626.CallOpts)
+}
+
+// TotalSupply is a free data retrieval call binding the contract method 0x18160ddd.
+//
+// Solidity: function totalSupply() view returns(uint256)
+func (_IERC4626 *IERC4626CallerSession) TotalSupply() (*big.Int, error) {
+	return _IERC4626.Contract.TotalSupply(&_IERC4626.CallOpts)
+}
+
+// Approve is a paid mutator transaction binding the contract method 0x095ea7b3.
+//
+// Solidity: function approve(address spender, uint256 amount) returns(bool)
+func (_IERC4626 *IERC4626Transactor) Approve(opts *bind.TransactOpts, spender common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _IERC4626.contract.Transact(opts, "approve", spender, amount)
+}
+
+// Approve is a paid mutator transaction binding the contract method 0x095ea7b3.
+//
+// Solidity: function approve(address spender, uint256 amount) returns(bool)
+func (_IERC4626 *IERC4626Session) Approve(spender common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _IERC4626.Contract.Approve(&_IERC4626.TransactOpts, spender, amount)
+}
+
+// Approve is a paid mutator transaction binding the contract method 0x095ea7b3.
+//
+// Solidity: function approve(address spender, uint256 amount) returns(bool)
+func (_IERC4626 *IERC4626TransactorSession) Approve(spender common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _IERC4626.Contract.Approve(&_IERC4626.TransactOpts, spender, amount)
+}
+
+// Deposit is a paid mutator transaction binding the contract method 0x6e553f65.
+//
+// Solidity: function deposit(uint256 assets, address receiver) returns(uint256 shares)
+func (_IERC4626 *IERC4626Transactor) Deposit(opts *bind.TransactOpts, assets *big.Int, receiver common.Address) (*types.Transaction, error) {
+	return _IERC4626.contract.Transact(opts, "deposit", assets, receiver)
+}
+
+// Deposit is a paid mutator transaction binding the contract method 0x6e553f65.
+//
+// Solidity: function deposit(uint256 assets, address receiver) returns(uint256 shares)
+func (_IERC4626 *IERC4626Session) Deposit(assets *big.Int, receiver common.Address) (*types.Transaction, error) {
+	return _IERC4626.Contract.Deposit(&_IERC4626.TransactOpts, assets, receiver)
+}
+
+// Deposit is a paid mutator transaction binding the contract method 0x6e553f65.
+//
+// Solidity: function deposit(uint256 assets, address receiver) returns(uint256 shares)
+func (_IERC4626 *IERC4626TransactorSession) Deposit(assets *big.Int, receiver common.Address) (*types.Transaction, error) {
+	return _IERC4626.Contract.Deposit(&_IERC4626.TransactOpts, assets, receiver)
+}
+
+// Mint is a paid mutator transaction binding the contract method 0x94bf804d.
+//
+// Solidity: function mint(uint256 shares, address receiver) returns(uint256 assets)
+func (_IERC4626 *IERC4626Transactor) Mint(opts *bind.TransactOpts, shares *big.Int, receiver common.Address) (*types.Transaction, error) {
+	return _IERC4626.contract.Transact(opts, "mint", shares, receiver)
+}
+
+// Mint is a paid mutator transaction binding the contract method 0x94bf804d.
+//
+// Solidity: function mint(uint256 shares, address receiver) returns(uint256 assets)
+func (_IERC4626 *IERC4626Session) Mint(shares *big.Int, receiver common.Address) (*types.Transaction, error) {
+	return _IERC4626.Contract.Mint(&_IERC4626.TransactOpts, shares, receiver)
+}
+
+// Mint is a paid mutator transaction binding the contract method 0x94bf804d.
+//
+// Solidity: function mint(uint256 shares, address receiver) returns(uint256 assets)
+func (_IERC4626 *IERC4626TransactorSession) Mint(shares *big.Int, receiver common.Address) (*types.Transaction, error) {
+	return _IERC4626.Contract.Mint(&_IERC4626.TransactOpts, shares, receiver)
+}
+
+// Redeem is a paid mutator transaction binding the contract method 0xba087652.
+//
+// Solidity: function redeem(uint256 shares, address receiver, address owner) returns(uint256 assets)
+func (_IERC4626 *IERC4626Transactor) Redeem(opts *bind.TransactOpts, shares *big.Int, receiver common.Address, owner common.Address) (*types.Transaction, error) {
+	return _IERC4626.contract.Transact(opts, "redeem", shares, receiver, owner)
+}
+
+// Redeem is a paid mutator transaction binding the contract method 0xba087652.
+//
+// Solidity: function redeem(uint256 shares, address receiver, address owner) returns(uint256 assets)
+func (_IERC4626 *IERC4626Session) Redeem(shares *big.Int, receiver common.Address, owner common.Address) (*types.Transaction, error) {
+	return _IERC4626.Contract.Redeem(&_IERC4626.TransactOpts, shares, receiver, owner)
+}
+
+// Redeem is a paid mutator transaction binding the contract method 0xba087652.
+//
+// Solidity: function redeem(uint256 shares, address receiver, address owner) returns(uint256 assets)
+func (_IERC4626 *IERC4626TransactorSession) Redeem(shares *big.Int, receiver common.Address, owner common.Address) (*types.Transaction, error) {
+	return _IERC4626.Contract.Redeem(&_IERC4626.TransactOpts, shares, receiver, owner)
+}
+
+// Transfer is a paid mutator transaction binding the contract method 0xa9059cbb.
+//
+// Solidity: function transfer(address to, uint256 amount) returns(bool)
+func (_IERC4626 *IERC4626Transactor) Transfer(opts *bind.TransactOpts, to common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _IERC4626.contract.Transact(opts, "transfer", to, amount)
+}
+
+// Transfer is a paid mutator transaction binding the contract method 0xa9059cbb.
+//
+// Solidity: function transfer(address to, uint256 amount) returns(bool)
+func (_IERC4626 *IERC4626Session) Transfer(to common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _IERC4626.Contract.Transfer(&_IERC4626.TransactOpts, to, amount)
+}
+
+// Transfer is a paid mutator transaction binding the contract method 0xa9059cbb.
+//
+// Solidity: function transfer(address to, uint256 amount) returns(bool)
+func (_IERC4626 *IERC4626TransactorSession) Transfer(to common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _IERC4626.Contract.Transfer(&_IERC4626.TransactOpts, to, amount)
+}
+
+// TransferFrom is a paid mutator transaction binding the contract method 0x23b872dd.
+//
+// Solidity: function transferFrom(address from, address to, uint256 amount) returns(bool)
+func (_IERC4626 *IERC4626Transactor) TransferFrom(opts *bind.TransactOpts, from common.Address, to common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _IERC4626.contract.Transact(opts, "transferFrom", from, to, amount)
+}
+
+// TransferFrom is a paid mutator transaction binding the contract method 0x23b872dd.
+//
+// Solidity: function transferFrom(address from, address to, uint256 amount) returns(bool)
+func (_IERC4626 *IERC4626Session) TransferFrom(from common.Address, to common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _IERC4626.Contract.TransferFrom(&_IERC4626.TransactOpts, from, to, amount)
+}
+
+// TransferFrom is a paid mutator transaction binding the contract method 0x23b872dd.
+//
+// Solidity: function transferFrom(address from, address to, uint256 amount) returns(bool)
+func (_IERC4626 *IERC4626TransactorSession) TransferFrom(from common.Address, to common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _IERC4626.Contract.TransferFrom(&_IERC4626.TransactOpts, from, to, amount)
+}
+
+// Withdraw is a paid mutator transaction binding the contract method 0xb460af94.
+//
+// Solidity: function withdraw(uint256 assets, address receiver, address owner) returns(uint256 shares)
+func (_IERC4626 *IERC4626Transactor) Withdraw(opts *bind.TransactOpts, assets *big.Int, receiver common.Address, owner common.Address) (*types.Transaction, error) {
+	return _IERC4626.contract.Transact(opts, "withdraw", assets, receiver, owner)
+}
+
+// Withdraw is a paid mutator transaction binding the contract method 0xb460af94.
+//
+// Solidity: function withdraw(uint256 assets, address receiver, address owner) returns(uint256 shares)
+func (_IERC4626 *IERC4626Session) Withdraw(assets *big.Int, receiver common.Address, owner common.Address) (*types.Transaction, error) {
+	return _IERC4626.Contract.Withdraw(&_IERC4626.TransactOpts, assets, receiver, owner)
+}
+
+// Withdraw is a paid mutator transaction binding the contract method 0xb460af94.
+//
+// Solidity: function withdraw(uint256 assets, address receiver, address owner) returns(uint256 shares)
+func (_IERC4626 *IERC4626TransactorSession) Withdraw(assets *big.Int, receiver common.Address, owner common.Address) (*types.Transaction, error) {
+	return _IERC4626.Contract.Withdraw(&_IERC4626.TransactOpts, assets, receiver, owner)
+}
+
+// IERC4626ApprovalIterator is returned from FilterApproval and is used to iterate over the raw logs and unpacked data for Approval events raised by the IERC4626 contract.
+type IERC4626ApprovalIterator struct {
+	Event *IERC4626Approval // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *IERC4626ApprovalIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(IERC4626Approval)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(IERC4626Approval)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *IERC4626ApprovalIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *IERC4626ApprovalIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// IERC4626Approval represents a Approval event raised by the IERC4626 contract.
+type IERC4626Approval struct {
+	Owner   common.Address
+	Spender common.Address
+	Value   *big.Int
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterApproval is a free log retrieval operation binding the contract event 0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925.
+//
+// Solidity: event Approval(address indexed owner, address indexed spender, uint256 value)
+func (_IERC4626 *IERC4626Filterer) FilterApproval(opts *bind.FilterOpts, owner []common.Address, spender []common.Address) (*IERC4626ApprovalIterator, error) {
+
+	var ownerRule []interface{}
+	for _, ownerItem := range owner {
+		ownerRule = append(ownerRule, ownerItem)
+	}
+	var spenderRule []interface{}
+	for _, spenderItem := range spender {
+		spenderRule = append(spenderRule, spenderItem)
+	}
+
+	logs, sub, err := _IERC4626.contract.FilterLogs(opts, "Approval", ownerRule, spenderRule)
+	if err != nil {
+		return nil, err
+	}
+	return &IERC4626ApprovalIterator{contract: _IERC4626.contract, event: "Approval", logs: logs, sub: sub}, nil
+}
+
+// WatchApproval is a free log subscription operation binding the contract event 0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925.
+//
+// Solidity: event Approval(address indexed owner, address indexed spender, uint256 value)
+func (_IERC4626 *IERC4626Filterer) WatchApproval(opts *bind.WatchOpts, sink chan<- *IERC4626Approval, owner []common.Address, spender []common.Address) (event.Subscription, error) {
+
+	var ownerRule []interface{}
+	for _, ownerItem := range owner {
+		ownerRule = append(ownerRule, ownerItem)
+	}
+	var spenderRule []interface{}
+	for _, spenderItem := range spender {
+		spenderRule = append(spenderRule, spenderItem)
+	}
+
+	logs, sub, err := _IERC4626.contract.WatchLogs(opts, "Approval", ownerRule, spenderRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(IERC4626Approval)
+				if err := _IERC4626.contract.UnpackLog(event, "Approval", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseApproval is a log parse operation binding the contract event 0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925.
+//
+// Solidity: event Approval(address indexed owner, address indexed spender, uint256 value)
+func (_IERC4626 *IERC4626Filterer) ParseApproval(log types.Log) (*IERC4626Approval, error) {
+	event := new(IERC4626Approval)
+	if err := _IERC4626.contract.UnpackLog(event, "Approval", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// IERC4626DepositIterator is returned from FilterDeposit and is used to iterate over the raw logs and unpacked data for Deposit events raised by the IERC4626 contract.
+type IERC4626DepositIterator struct {
+	Event *IERC4626Deposit // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *IERC4626DepositIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(IERC4626Deposit)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(IERC4626Deposit)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *IERC4626DepositIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *IERC4626DepositIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// IERC4626Deposit represents a Deposit event raised by the IERC4626 contract.
+type IERC4626Deposit struct {
+	Sender common.Address
+	Owner  common.Address
+	Assets *big.Int
+	Shares *big.Int
+	Raw    types.Log // Blockchain specific contextual infos
+}
+
+// FilterDeposit is a free log retrieval operation binding the contract event 0xdcbc1c05240f31ff3ad067ef1ee35ce4997762752e3a095284754544f4c709d7.
+//
+// Solidity: event Deposit(address indexed sender, address indexed owner, uint256 assets, uint256 shares)
+func (_IERC4626 *IERC4626Filterer) FilterDeposit(opts *bind.FilterOpts, sender []common.Address, owner []common.Address) (*IERC4626DepositIterator, error) {
+
+	var senderRule []interface{}
+	for _, senderItem := range sender {
+		senderRule = append(senderRule, senderItem)
+	}
+	var ownerRule []interface{}
+	for _, ownerItem := range owner {
+		ownerRule = append(ownerRule, ownerItem)
+	}
+
+	logs, sub, err := _IERC4626.contract.FilterLogs(opts, "Deposit", senderRule, ownerRule)
+	if err != nil {
+		return nil, err
+	}
+	return &IERC4626DepositIterator{contract: _IERC4626.contract, event: "Deposit", logs: logs, sub: sub}, nil
+}
+
+// WatchDeposit is a free log subscription operation binding the contract event 0xdcbc1c05240f31ff3ad067ef1ee35ce4997762752e3a095284754544f4c709d7.
+//
+// Solidity: event Deposit(address indexed sender, address indexed owner, uint256 assets, uint256 shares)
+func (_IERC4626 *IERC4626Filterer) WatchDeposit(opts *bind.WatchOpts, sink chan<- *IERC4626Deposit, sender []common.Address, owner []common.Address) (event.Subscription, error) {
+
+	var senderRule []interface{}
+	for _, senderItem := range sender {
+		senderRule = append(senderRule, senderItem)
+	}
+	var ownerRule []interface{}
+	for _, ownerItem := range owner {
+		ownerRule = append(ownerRule, ownerItem)
+	}
+
+	logs, sub, err := _IERC4626.contract.WatchLogs(opts, "Deposit", senderRule, ownerRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(IERC4626Deposit)
+				if err := _IERC4626.contract.UnpackLog(event, "Deposit", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseDeposit is a log parse operation binding the contract event 0xdcbc1c05240f31ff3ad067ef1ee35ce4997762752e3a095284754544f4c709d7.
+//
+// Solidity: event Deposit(address indexed sender, address indexed owner, uint256 assets, uint256 shares)
+func (_IERC4626 *IERC4626Filterer) ParseDeposit(log types.Log) (*IERC4626Deposit, error) {
+	event := new(IERC4626Deposit)
+	if err := _IERC4626.contract.UnpackLog(event, "Deposit", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// IERC4626TransferIterator is returned from FilterTransfer and is used to iterate over the raw logs and unpacked data for Transfer events raised by the IERC4626 contract.
+type IERC4626TransferIterator struct {
+	Event *IERC4626Transfer // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *IERC4626TransferIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(IERC4626Transfer)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(IERC4626Transfer)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *IERC4626TransferIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *IERC4626TransferIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// IERC4626Transfer represents a Transfer event raised by the IERC4626 contract.
+type IERC4626Transfer struct {
+	From  common.Address
+	To    common.Address
+	Value *big.Int
+	Raw   types.Log // Blockchain specific contextual infos
+}
+
+// FilterTransfer is a free log retrieval operation binding the contract event 0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef.
+//
+// Solidity: event Transfer(address indexed from, address indexed to, uint256 value)
+func (_IERC4626 *IERC4626Filterer) FilterTransfer(opts *bind.FilterOpts, from []common.Address, to []common.Address) (*IERC4626TransferIterator, error) {
+
+	var fromRule []interface{}
+	for _, fromItem := range from {
+		fromRule = append(fromRule, fromItem)
+	}
+	var toRule []interface{}
+	for _, toItem := range to {
+		toRule = append(toRule, toItem)
+	}
+
+	logs, sub, err := _IERC4626.contract.FilterLogs(opts, "Transfer", fromRule, toRule)
+	if err != nil {
+		return nil, err
+	}
+	return &IERC4626TransferIterator{contract: _IERC4626.contract, event: "Transfer", logs: logs, sub: sub}, nil
+}
+
+// WatchTransfer is a free log subscription operation binding the contract event 0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef.
+//
+// Solidity: event Transfer(address indexed from, address indexed to, uint256 value)
+func (_IERC4626 *IERC4626Filterer) WatchTransfer(opts *bind.WatchOpts, sink chan<- *IERC4626Transfer, from []common.Address, to []common.Address) (event.Subscription, error) {
+
+	var fromRule []interface{}
+	for _, fromItem := range from {
+		fromRule = append(fromRule, fromItem)
+	}
+	var toRule []interface{}
+	for _, toItem := range to {
+		toRule = append(toRule, toItem)
+	}
+
+	logs, sub, err := _IERC4626.contract.WatchLogs(opts, "Transfer", fromRule, toRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(IERC4626Transfer)
+				if err := _IERC4626.contract.UnpackLog(event, "Transfer", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseTransfer is a log parse operation binding the contract event 0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef.
+//
+// Solidity: event Transfer(address indexed from, address indexed to, uint256 value)
+func (_IERC4626 *IERC4626Filterer) ParseTransfer(log types.Log) (*IERC4626Transfer, error) {
+	event := new(IERC4626Transfer)
+	if err := _IERC4626.contract.UnpackLog(event, "Transfer", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// IERC4626WithdrawIterator is returned from FilterWithdraw and is used to iterate over the raw logs and unpacked data for Withdraw events raised by the IERC4626 contract.
+type IERC4626WithdrawIterator struct {
+	Event *IERC4626Withdraw // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *IERC4626WithdrawIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(IERC4626Withdraw)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(IERC4626Withdraw)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *IERC4626WithdrawIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *IERC4626WithdrawIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// IERC4626Withdraw represents a Withdraw event raised by the IERC4626 contract.
+type IERC4626Withdraw struct {
+	Sender   common.Address
+	Receiver common.Address
+	Owner    common.Address
+	Assets   *big.Int
+	Shares   *big.Int
+	Raw      types.Log // Blockchain specific contextual infos
+}
+
+// FilterWithdraw is a free log retrieval operation binding the contract event 0xfbde797d201c681b91056529119e0b02407c7bb96a4a2c75c01fc9667232c8db.
+//
+// Solidity: event Withdraw(address indexed sender, address indexed receiver, address indexed owner, uint256 assets, uint256 shares)
+func (_IERC4626 *IERC4626Filterer) FilterWithdraw(opts *bind.FilterOpts, sender []common.Address, receiver []common.Address, owner []common.Address) (*IERC4626WithdrawIterator, error) {
+
+	var senderRule []interface{}
+	for _, senderItem := range sender {
+		senderRule = append(senderRule, senderItem)
+	}
+	var receiverRule []interface{}
+	for _, receiverItem := range receiver {
+		receiverRule = append(receiverRule, receiverItem)
+	}
+	var ownerRule []interface{}
+	for _, ownerItem := range owner {
+		ownerRule = append(ownerRule, ownerItem)
+	}
+
+	logs, sub, err := _IERC4626.contract.FilterLogs(opts, "Withdraw", senderRule, receiverRule, ownerRule)
+	if err != nil {
+		return nil, err
+	}
+	return &IERC4626WithdrawIterator{contract: _IERC4626.contract, event: "Withdraw", logs: logs, sub: sub}, nil
+}
+
+// WatchWithdraw is a free log subscription operation binding the contract event 0xfbde797d201c681b91056529119e0b02407c7bb96a4a2c75c01fc9667232c8db.
+//
+// Solidity: event Withdraw(address indexed sender, address indexed receiver, address indexed owner, uint256 assets, uint256 shares)
+func (_IERC4626 *IERC4626Filterer) WatchWithdraw(opts *bind.WatchOpts, sink chan<- *IERC4626Withdraw, sender []common.Address, receiver []common.Address, owner []common.Address) (event.Subscription, error) {
+
+	var senderRule []interface{}
+	for _, senderItem := range sender {
+		senderRule = append(senderRule, senderItem)
+	}
+	var receiverRule []interface{}
+	for _, receiverItem := range receiver {
+		receiverRule = append(receiverRule, receiverItem)
+	}
+	var ownerRule []interface{}
+	for _, ownerItem := range owner {
+		ownerRule = append(ownerRule, ownerItem)
+	}
+
+	logs, sub, err := _IERC4626.contract.WatchLogs(opts, "Withdraw", senderRule, receiverRule, ownerRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(IERC4626Withdraw)
+				if err := _IERC4626.contract.UnpackLog(event, "Withdraw", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseWithdraw is a log parse operation binding the contract event 0xfbde797d201c681b91056529119e0b02407c7bb96a4a2c75c01fc9667232c8db.
+//
+// Solidity: event Withdraw(address indexed sender, address indexed receiver, address indexed owner, uint256 assets, uint256 shares)
+func (_IERC4626 *IERC4626Filterer) ParseWithdraw(log types.Log) (*IERC4626Withdraw, error) {
+	event := new(IERC4626Withdraw)
+	if err := _IERC4626.contract.UnpackLog(event, "Withdraw", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
@@ -0,0 +1,845 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package IUniswapV3Pool
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+)
+
+// IUniswapV3PoolMetaData contains all meta data concerning the IUniswapV3Pool contract.
+var IUniswapV3PoolMetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[],\"name\":\"factory\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"fee\",\"outputs\":[{\"internalType\":\"uint24\",\"name\":\"\",\"type\":\"uint24\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"feeGrowthGlobal0X128\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"feeGrowthGlobal1X128\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"liquidity\",\"outputs\":[{\"internalType\":\"uint128\",\"name\":\"\",\"type\":\"uint128\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"maxLiquidityPerTick\",\"outputs\":[{\"internalType\":\"uint128\",\"name\":\"\",\"type\":\"uint128\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"index\",\"type\":\"uint256\"}],\"name\":\"observations\",\"outputs\":[{\"internalType\":\"uint32\",\"name\":\"blockTimestamp\",\"type\":\"uint32\"},{\"internalType\":\"int56\",\"name\":\"tickCumulative\",\"type\":\"int56\"},{\"internalType\":\"uint160\",\"name\":\"secondsPerLiquidityCumulativeX128\",\"type\":\"uint160\"},{\"internalType\":\"bool\",\"name\":\"initialized\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint32[]\",\"name\":\"secondsAgos\",\"type\":\"uint32[]\"}],\"name\":\"observe\",\"outputs\":[{\"internalType\":\"int56[]\",\"name\":\"tickCumulatives\",\"type\":\"int56[]\"},{\"internalType\":\"uint160[]\",\"name\":\"secondsPerLiquidityCumulativeX128s\",\"type\":\"uint160[]\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"key\",\"type\":\"bytes32\"}],\"name\":\"positions\",\"outputs\":[{\"internalType\":\"uint128\",\"name\":\"_liquidity\",\"type\":\"uint128\"},{\"internalType\":\"uint256\",\"name\":\"feeGrowthInside0LastX128\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"feeGrowthInside1LastX128\",\"type\":\"uint256\"},{\"internalType\":\"uint128\",\"name\":\"tokensOwed0\",\"type\":\"uint128\"},{\"internalType\":\"uint128\",\"name\":\"tokensOwed1\",\"type\":\"uint128\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"slot0\",\"outputs\":[{\"internalType\":\"uint160\",\"name\":\"sqrtPriceX96\",\"type\":\"uint160\"},{\"internalType\":\"int24\",\"name\":\"tick\",\"type\":\"int24\"},{\"internalType\":\"uint16\",\"name\":\"observationIndex\",\"type\":\"uint16\"},{\"internalType\":\"uint16\",\"name\":\"observationCardinality\",\"type\":\"uint16\"},{\"internalType\":\"uint16\",\"name\":\"observationCardinalityNext\",\"type\":\"uint16\"},{\"internalType\":\"uint8\",\"name\":\"feeProtocol\",\"type\":\"uint8\"},{\"internalType\":\"bool\",\"name\":\"unlocked\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"int24\",\"name\":\"tickLower\",\"type\":\"int24\"},{\"internalType\":\"int24\",\"name\":\"tickUpper\",\"type\":\"int24\"}],\"name\":\"snapshotCumulativesInside\",\"outputs\":[{\"internalType\":\"int56\",\"name\":\"tickCumulativeInside\",\"type\":\"int56\"},{\"internalType\":\"uint160\",\"name\":\"secondsPerLiquidityInsideX128\",\"type\":\"uint160\"},{\"internalType\":\"uint32\",\"name\":\"secondsInside\",\"type\":\"uint32\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"int16\",\"name\":\"wordPosition\",\"type\":\"int16\"}],\"name\":\"tickBitmap\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"tickSpacing\",\"outputs\":[{\"internalType\":\"int24\",\"name\":\"\",\"type\":\"int24\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"int24\",\"name\":\"tick\",\"type\":\"int24\"}],\"name\":\"ticks\",\"outputs\":[{\"internalType\":\"uint128\",\"name\":\"liquidityGross\",\"type\":\"uint128\"},{\"internalType\":\"int128\",\"name\":\"liquidityNet\",\"type\":\"int128\"},{\"internalType\":\"uint256\",\"name\":\"feeGrowthOutside0X128\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"feeGrowthOutside1X128\",\"type\":\"uint256\"},{\"internalType\":\"int56\",\"name\":\"tickCumulativeOutside\",\"type\":\"int56\"},{\"internalType\":\"uint160\",\"name\":\"secondsPerLiquidityOutsideX128\",\"type\":\"uint160\"},{\"internalType\":\"uint32\",\"name\":\"secondsOutside\",\"type\":\"uint32\"},{\"internalType\":\"bool\",\"name\":\"initialized\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"token0\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"token1\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"}]",
+}
+
+// IUniswapV3PoolABI is the input ABI used to generate the binding from.
+// Deprecated: Use IUniswapV3PoolMetaData.ABI instead.
+var IUniswapV3PoolABI = IUniswapV3PoolMetaData.ABI
+
+// IUniswapV3Pool is an auto generated Go binding around an Ethereum contract.
+type IUniswapV3Pool struct {
+	IUniswapV3PoolCaller     // Read-only binding to the contract
+	IUniswapV3PoolTransactor // Write-only binding to the contract
+	IUniswapV3PoolFilterer   // Log filterer for contract events
+}
+
+// IUniswapV3PoolCaller is an auto generated read-only Go binding around an Ethereum contract.
+type IUniswapV3PoolCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// IUniswapV3PoolTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type IUniswapV3PoolTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// IUniswapV3PoolFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type IUniswapV3PoolFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// IUniswapV3PoolSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type IUniswapV3PoolSession struct {
+	Contract     *IUniswapV3Pool   // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// IUniswapV3PoolCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type IUniswapV3PoolCallerSession struct {
+	Contract *IUniswapV3PoolCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts         // Call options to use throughout this session
+}
+
+// IUniswapV3PoolTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type IUniswapV3PoolTransactorSession struct {
+	Contract     *IUniswapV3PoolTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts         // Transaction auth options to use throughout this session
+}
+
+// IUniswapV3PoolRaw is an auto generated low-level Go binding around an Ethereum contract.
+type IUniswapV3PoolRaw struct {
+	Contract *IUniswapV3Pool // Generic contract binding to access the raw methods on
+}
+
+// IUniswapV3PoolCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type IUniswapV3PoolCallerRaw struct {
+	Contract *IUniswapV3PoolCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// IUniswapV3PoolTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type IUniswapV3PoolTransactorRaw struct {
+	Contract *IUniswapV3PoolTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewIUniswapV3Pool creates a new instance of IUniswapV3Pool, bound to a specific deployed contract.
+func NewIUniswapV3Pool(address common.Address, backend bind.ContractBackend) (*IUniswapV3Pool, error) {
+	contract, err := bindIUniswapV3Pool(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &IUniswapV3Pool{IUniswapV3PoolCaller: IUniswapV3PoolCaller{contract: contract}, IUniswapV3PoolTransactor: IUniswapV3PoolTransactor{contract: contract}, IUniswapV3PoolFilterer: IUniswapV3PoolFilterer{contract: contract}}, nil
+}
+
+// NewIUniswapV3PoolCaller creates a new read-only instance of IUniswapV3Pool, bound to a specific deployed contract.
+func NewIUniswapV3PoolCaller(address common.Address, caller bind.ContractCaller) (*IUniswapV3PoolCaller, error) {
+	contract, err := bindIUniswapV3Pool(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &IUniswapV3PoolCaller{contract: contract}, nil
+}
+
+// NewIUniswapV3PoolTransactor creates a new write-only instance of IUniswapV3Pool, bound to a specific deployed contract.
+func NewIUniswapV3PoolTransactor(address common.Address, transactor bind.ContractTransactor) (*IUniswapV3PoolTransactor, error) {
+	contract, err := bindIUniswapV3Pool(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &IUniswapV3PoolTransactor{contract: contract}, nil
+}
+
+// NewIUniswapV3PoolFilterer creates a new log filterer instance of IUniswapV3Pool, bound to a specific deployed contract.
+func NewIUniswapV3PoolFilterer(address common.Address, filterer bind.ContractFilterer) (*IUniswapV3PoolFilterer, error) {
+	contract, err := bindIUniswapV3Pool(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &IUniswapV3PoolFilterer{contract: contract}, nil
+}
+
+// bindIUniswapV3Pool binds a generic wrapper to an already deployed contract.
+func bindIUniswapV3Pool(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := abi.JSON(strings.NewReader(IUniswapV3PoolABI))
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_IUniswapV3Pool *IUniswapV3PoolRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _IUniswapV3Pool.Contract.IUniswapV3PoolCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_IUniswapV3Pool *IUniswapV3PoolRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _IUniswapV3Pool.Contract.IUniswapV3PoolTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_IUniswapV3Pool *IUniswapV3PoolRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _IUniswapV3Pool.Contract.IUniswapV3PoolTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_IUniswapV3Pool *IUniswapV3PoolCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _IUniswapV3Pool.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_IUniswapV3Pool *IUniswapV3PoolTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _IUniswapV3Pool.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_IUniswapV3Pool *IUniswapV3PoolTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _IUniswapV3Pool.Contract.contract.Transact(opts, method, params...)
+}
+
+// Factory is a free data retrieval call binding the contract method 0xc45a0155.
+//
+// Solidity: function factory() view returns(address)
+func (_IUniswapV3Pool *IUniswapV3PoolCaller) Factory(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _IUniswapV3Pool.contract.Call(opts, &out, "factory")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Factory is a free data retrieval call binding the contract method 0xc45a0155.
+//
+// Solidity: function factory() view returns(address)
+func (_IUniswapV3Pool *IUniswapV3PoolSession) Factory() (common.Address, error) {
+	return _IUniswapV3Pool.Contract.Factory(&_IUniswapV3Pool.CallOpts)
+}
+
+// Factory is a free data retrieval call binding the contract method 0xc45a0155.
+//
+// Solidity: function factory() view returns(address)
+func (_IUniswapV3Pool *IUniswapV3PoolCallerSession) Factory() (common.Address, error) {
+	return _IUniswapV3Pool.Contract.Factory(&_IUniswapV3Pool.CallOpts)
+}
+
+// Fee is a free data retrieval call binding the contract method 0xddca3f43.
+//
+// Solidity: function fee() view returns(uint24)
+func (_IUniswapV3Pool *IUniswapV3PoolCaller) Fee(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _IUniswapV3Pool.contract.Call(opts, &out, "fee")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// Fee is a free data retrieval call binding the contract method 0xddca3f43.
+//
+// Solidity: function fee() view returns(uint24)
+func (_IUniswapV3Pool *IUniswapV3PoolSession) Fee() (*big.Int, error) {
+	return _IUniswapV3Pool.Contract.Fee(&_IUniswapV3Pool.CallOpts)
+}
+
+// Fee is a free data retrieval call binding the contract method 0xddca3f43.
+//
+// Solidity: function fee() view returns(uint24)
+func (_IUniswapV3Pool *IUniswapV3PoolCallerSession) Fee() (*big.Int, error) {
+	return _IUniswapV3Pool.Contract.Fee(&_IUniswapV3Pool.CallOpts)
+}
+
+// FeeGrowthGlobal0X128 is a free data retrieval call binding the contract method 0xf3058399.
+//
+// Solidity: function feeGrowthGlobal0X128() view returns(uint256)
+func (_IUniswapV3Pool *IUniswapV3PoolCaller) FeeGrowthGlobal0X128(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _IUniswapV3Pool.contract.Call(opts, &out, "feeGrowthGlobal0X128")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// FeeGrowthGlobal0X128 is a free data retrieval call binding the contract method 0xf3058399.
+//
+// Solidity: function feeGrowthGlobal0X128() view returns(uint256)
+func (_IUniswapV3Pool *IUniswapV3PoolSession) FeeGrowthGlobal0X128() (*big.Int, error) {
+	return _IUniswapV3Pool.Contract.FeeGrowthGlobal0X128(&_IUniswapV3Pool.CallOpts)
+}
+
+// FeeGrowthGlobal0X128 is a free data retrieval call binding the contract method 0xf3058399.
+//
+// Solidity: function feeGrowthGlobal0X128() view returns(uint256)
+func (_IUniswapV3Pool *IUniswapV3PoolCallerSession) FeeGrowthGlobal0X128() (*big.Int, error) {
+	return _IUniswapV3Pool.Contract.FeeGrowthGlobal0X128(&_IUniswapV3Pool.CallOpts)
+}
+
+// FeeGrowthGlobal1X128 is a free data retrieval call binding the contract method 0x46141319.
+//
+// Solidity: function feeGrowthGlobal1X128() view returns(uint256)
+func (_IUniswapV3Pool *IUniswapV3PoolCaller) FeeGrowthGlobal1X128(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _IUniswapV3Pool.contract.Call(opts, &out, "feeGrowthGlobal1X128")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// FeeGrowthGlobal1X128 is a free data retrieval call binding the contract method 0x46141319.
+//
+// Solidity: function feeGrowthGlobal1X128() view returns(uint256)
+func (_IUniswapV3Pool *IUniswapV3PoolSession) FeeGrowthGlobal1X128() (*big.Int, error) {
+	return _IUniswapV3Pool.Contract.FeeGrowthGlobal1X128(&_IUniswapV3Pool.CallOpts)
+}
+
+// FeeGrowthGlobal1X128 is a free data retrieval call binding the contract method 0x46141319.
+//
+// Solidity: function feeGrowthGlobal1X128() view returns(uint256)
+func (_IUniswapV3Pool *IUniswapV3PoolCallerSession) FeeGrowthGlobal1X128() (*big.Int, error) {
+	return _IUniswapV3Pool.Contract.FeeGrowthGlobal1X128(&_IUniswapV3Pool.CallOpts)
+}
+
+// Liquidity is a free data retrieval call binding the contract method 0x1a686502.
+//
+// Solidity: function liquidity() view returns(uint128)
+func (_IUniswapV3Pool *IUniswapV3PoolCaller) Liquidity(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _IUniswapV3Pool.contract.Call(opts, &out, "liquidity")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// Liquidity is a free data retrieval call binding the contract method 0x1a686502.
+//
+// Solidity: function liquidity() view returns(uint128)
+func (_IUniswapV3Pool *IUniswapV3PoolSession) Liquidity() (*big.Int, error) {
+	return _IUniswapV3Pool.Contract.Liquidity(&_IUniswapV3Pool.CallOpts)
+}
+
+// Liquidity is a free data retrieval call binding the contract method 0x1a686502.
+//
+// Solidity: function liquidity() view returns(uint128)
+func (_IUniswapV3Pool *IUniswapV3PoolCallerSession) Liquidity() (*big.Int, error) {
+	return _IUniswapV3Pool.Contract.Liquidity(&_IUniswapV3Pool.CallOpts)
+}
+
+// MaxLiquidityPerTick is a free data retrieval call binding the contract method 0x70cf754a.
+//
+// Solidity: function maxLiquidityPerTick() view returns(uint128)
+func (_IUniswapV3Pool *IUniswapV3PoolCaller) MaxLiquidityPerTick(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _IUniswapV3Pool.contract.Call(opts, &out, "maxLiquidityPerTick")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// MaxLiquidityPerTick is a free data retrieval call binding the contract method 0x70cf754a.
+//
+// Solidity: function maxLiquidityPerTick() view returns(uint128)
+func (_IUniswapV3Pool *IUniswapV3PoolSession) MaxLiquidityPerTick() (*big.Int, error) {
+	return _IUniswapV3Pool.Contract.MaxLiquidityPerTick(&_IUniswapV3Pool.CallOpts)
+}
+
+// MaxLiquidityPerTick is a free data retrieval call binding the contract method 0x70cf754a.
+//
+// Solidity: function maxLiquidityPerTick() view returns(uint128)
+func (_IUniswapV3Pool *IUniswapV3PoolCallerSession) MaxLiquidityPerTick() (*big.Int, error) {
+	return _IUniswapV3Pool.Contract.MaxLiquidityPerTick(&_IUniswapV3Pool.CallOpts)
+}
+
+// Observations is a free data retrieval call binding the contract method 0x252c09d7.
+//
+// Solidity: function observations(uint256 index) view returns(uint32 blockTimestamp, int56 tickCumulative, uint160 secondsPerLiquidityCumulativeX128, bool initialized)
+func (_IUniswapV3Pool *IUniswapV3PoolCaller) Observations(opts *bind.CallOpts, index *big.Int) (struct {
+	BlockTimestamp                    uint32
+	TickCumulative                    *big.Int
+	SecondsPerLiquidityCumulativeX128 *big.Int
+	Initialized                       bool
+}, error) {
+	var out []interface{}
+	err := _IUniswapV3Pool.contract.Call(opts, &out, "observations", index)
+
+	outstruct := new(struct {
+		BlockTimestamp                    uint32
+		TickCumulative                    *big.Int
+		SecondsPerLiquidityCumulativeX128 *big.Int
+		Initialized                       bool
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.BlockTimestamp = *abi.ConvertType(out[0], new(uint32)).(*uint32)
+	outstruct.TickCumulative = *abi.ConvertType(out[1], new(*big.Int)).(**big.Int)
+	outstruct.SecondsPerLiquidityCumulativeX128 = *abi.ConvertType(out[2], new(*big.Int)).(**big.Int)
+	outstruct.Initialized = *abi.ConvertType(out[3], new(bool)).(*bool)
+
+	return *outstruct, err
+
+}
+
+// Observations is a free data retrieval call binding the contract method 0x252c09d7.
+//
+// Solidity: function observations(uint256 index) view returns(uint32 blockTimestamp, int56 tickCumulative, uint160 secondsPerLiquidityCumulativeX128, bool initialized)
+func (_IUniswapV3Pool *IUniswapV3PoolSession) Observations(index *big.Int) (struct {
+	BlockTimestamp                    uint32
+	TickCumulative                    *big.Int
+	SecondsPerLiquidityCumulativeX128 *big.Int
+	Initialized                       bool
+}, error) {
+	return _IUniswapV3Pool.Contract.Observations(&_IUniswapV3Pool.CallOpts, index)
+}
+
+// Observations is a free data retrieval call binding the contract method 0x252c09d7.
+//
+// Solidity: function observations(uint256 index) view returns(uint32 blockTimestamp, int56 tickCumulative, uint160 secondsPerLiquidityCumulativeX128, bool initialized)
+func (_IUniswapV3Pool *IUniswapV3PoolCallerSession) Observations(index *big.Int) (struct {
+	BlockTimestamp                    uint32
+	TickCumulative                    *big.Int
+	SecondsPerLiquidityCumulativeX128 *big.Int
+	Initialized                       bool
+}, error) {
+	return _IUniswapV3Pool.Contract.Observations(&_IUniswapV3Pool.CallOpts, index)
+}
+
+// Observe is a free data retrieval call binding the contract method 0x883bdbfd.
+//
+// Solidity: function observe(uint32[] secondsAgos) view returns(int56[] tickCumulatives, uint160[] secondsPerLiquidityCumulativeX128s)
+func (_IUniswapV3Pool *IUniswapV3PoolCaller) Observe(opts *bind.CallOpts, secondsAgos []uint32) (struct {
+	TickCumulatives                    []*big.Int
+	SecondsPerLiquidityCumulativeX128s []*big.Int
+}, error) {
+	var out []interface{}
+	err := _IUniswapV3Pool.contract.Call(opts, &out, "observe", secondsAgos)
+
+	outstruct := new(struct {
+		TickCumulatives                    []*big.Int
+		SecondsPerLiquidityCumulativeX128s []*big.Int
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.TickCumulatives = *abi.ConvertType(out[0], new([]*big.Int)).(*[]*big.Int)
+	outstruct.SecondsPerLiquidityCumulativeX128s = *abi.ConvertType(out[1], new([]*big.Int)).(*[]*big.Int)
+
+	return *outstruct, err
+
+}
+
+// Observe is a free data retrieval call binding the contract method 0x883bdbfd.
+//
+// Solidity: function observe(uint32[] secondsAgos) view returns(int56[] tickCumulatives, uint160[] secondsPerLiquidityCumulativeX128s)
+func (_IUniswapV3Pool *IUniswapV3PoolSession) Observe(secondsAgos []uint32) (struct {
+	TickCumulatives                    []*big.Int
+	SecondsPerLiquidityCumulativeX128s []*big.Int
+}, error) {
+	return _IUniswapV3Pool.Contract.Observe(&_IUniswapV3Pool.CallOpts, secondsAgos)
+}
+
+// Observe is a free data retrieval call binding the contract method 0x883bdbfd.
+//
+// Solidity: function observe(uint32[] secondsAgos) view returns(int56[] tickCumulatives, uint160[] secondsPerLiquidityCumulativeX128s)
+func (_IUniswapV3Pool *IUniswapV3PoolCallerSession) Observe(secondsAgos []uint32) (struct {
+	TickCumulatives                    []*big.Int
+	SecondsPerLiquidityCumulativeX128s []*big.Int
+}, error) {
+	return _IUniswapV3Pool.Contract.Observe(&_IUniswapV3Pool.CallOpts, secondsAgos)
+}
+
+// Positions is a free data retrieval call binding the contract method 0x514ea4bf.
+//
+// Solidity: function positions(bytes32 key) view returns(uint128 _liquidity, uint256 feeGrowthInside0LastX128, uint256 feeGrowthInside1LastX128, uint128 tokensOwed0, uint128 tokensOwed1)
+func (_IUniswapV3Pool *IUniswapV3PoolCaller) Positions(opts *bind.CallOpts, key [32]byte) (struct {
+	Liquidity                *big.Int
+	FeeGrowthInside0LastX128 *big.Int
+	FeeGrowthInside1LastX128 *big.Int
+	TokensOwed0              *big.Int
+	TokensOwed1              *big.Int
+}, error) {
+	var out []interface{}
+	err := _IUniswapV3Pool.contract.Call(opts, &out, "positions", key)
+
+	outstruct := new(struct {
+		Liquidity                *big.Int
+		FeeGrowthInside0LastX128 *big.Int
+		FeeGrowthInside1LastX128 *big.Int
+		TokensOwed0              *big.Int
+		TokensOwed1              *big.Int
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.Liquidity = *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+	outstruct.FeeGrowthInside0LastX128 = *abi.ConvertType(out[1], new(*big.Int)).(**big.Int)
+	outstruct.FeeGrowthInside1LastX128 = *abi.ConvertType(out[2], new(*big.Int)).(**big.Int)
+	outstruct.TokensOwed0 = *abi.ConvertType(out[3], new(*big.Int)).(**big.Int)
+	outstruct.TokensOwed1 = *abi.ConvertType(out[4], new(*big.Int)).(**big.Int)
+
+	return *outstruct, err
+
+}
+
+// Positions is a free data retrieval call binding the contract method 0x514ea4bf.
+//
+// Solidity: function positions(bytes32 key) view returns(uint128 _liquidity, uint256 feeGrowthInside0LastX128, uint256 feeGrowthInside1LastX128, uint128 tokensOwed0, uint128 tokensOwed1)
+func (_IUniswapV3Pool *IUniswapV3PoolSession) Positions(key [32]byte) (struct {
+	Liquidity                *big.Int
+	FeeGrowthInside0LastX128 *big.Int
+	FeeGrowthInside1LastX128 *big.Int
+	TokensOwed0              *big.Int
+	TokensOwed1              *big.Int
+}, error) {
+	return _IUniswapV3Pool.Contract.Positions(&_IUniswapV3Pool.CallOpts, key)
+}
+
+// Positions is a free data retrieval call binding the contract method 0x514ea4bf.
+//
+// Solidity: function positions(bytes32 key) view returns(uint128 _liquidity, uint256 feeGrowthInside0LastX128, uint256 feeGrowthInside1LastX128, uint128 tokensOwed0, uint128 tokensOwed1)
+func (_IUniswapV3Pool *IUniswapV3PoolCallerSession) Positions(key [32]byte) (struct {
+	Liquidity                *big.Int
+	FeeGrowthInside0LastX128 *big.Int
+	FeeGrowthInside1LastX128 *big.Int
+	TokensOwed0              *big.Int
+	TokensOwed1              *big.Int
+}, error) {
+	return _IUniswapV3Pool.Contract.Positions(&_IUniswapV3Pool.CallOpts, key)
+}
+
+// Slot0 is a free data retrieval call binding the contract method 0x3850c7bd.
+//
+// Solidity: function slot0() view returns(uint160 sqrtPriceX96, int24 tick, uint16 observationIndex, uint16 observationCardinality, uint16 observationCardinalityNext, uint8 feeProtocol, bool unlocked)
+func (_IUniswapV3Pool *IUniswapV3PoolCaller) Slot0(opts *bind.CallOpts) (struct {
+	SqrtPriceX96               *big.Int
+	Tick                       *big.Int
+	ObservationIndex           uint16
+	ObservationCardinality     uint16
+	ObservationCardinalityNext uint16
+	FeeProtocol                uint8
+	Unlocked                   bool
+}, error) {
+	var out []interface{}
+	err := _IUniswapV3Pool.contract.Call(opts, &out, "slot0")
+
+	outstruct := new(struct {
+		SqrtPriceX96               *big.Int
+		Tick                       *big.Int
+		ObservationIndex           uint16
+		ObservationCardinality     uint16
+		ObservationCardinalityNext uint16
+		FeeProtocol                uint8
+		Unlocked                   bool
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.SqrtPriceX96 = *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+	outstruct.Tick = *abi.ConvertType(out[1], new(*big.Int)).(**big.Int)
+	outstruct.ObservationIndex = *abi.ConvertType(out[2], new(uint16)).(*uint16)
+	outstruct.ObservationCardinality = *abi.ConvertType(out[3], new(uint16)).(*uint16)
+	outstruct.ObservationCardinalityNext = *abi.ConvertType(out[4], new(uint16)).(*uint16)
+	outstruct.FeeProtocol = *abi.ConvertType(out[5], new(uint8)).(*uint8)
+	outstruct.Unlocked = *abi.ConvertType(out[6], new(bool)).(*bool)
+
+	return *outstruct, err
+
+}
+
+// Slot0 is a free data retrieval call binding the contract method 0x3850c7bd.
+//
+// Solidity: function slot0() view returns(uint160 sqrtPriceX96, int24 tick, uint16 observationIndex, uint16 observationCardinality, uint16 observationCardinalityNext, uint8 feeProtocol, bool unlocked)
+func (_IUniswapV3Pool *IUniswapV3PoolSession) Slot0() (struct {
+	SqrtPriceX96               *big.Int
+	Tick                       *big.Int
+	ObservationIndex           uint16
+	ObservationCardinality     uint16
+	ObservationCardinalityNext uint16
+	FeeProtocol                uint8
+	Unlocked                   bool
+}, error) {
+	return _IUniswapV3Pool.Contract.Slot0(&_IUniswapV3Pool.CallOpts)
+}
+
+// Slot0 is a free data retrieval call binding the contract method 0x3850c7bd.
+//
+// Solidity: function slot0() view returns(uint160 sqrtPriceX96, int24 tick, uint16 observationIndex, uint16 observationCardinality, uint16 observationCardinalityNext, uint8 feeProtocol, bool unlocked)
+func (_IUniswapV3Pool *IUniswapV3PoolCallerSession) Slot0() (struct {
+	SqrtPriceX96               *big.Int
+	Tick                       *big.Int
+	ObservationIndex           uint16
+	ObservationCardinality     uint16
+	ObservationCardinalityNext uint16
+	FeeProtocol                uint8
+	Unlocked                   bool
+}, error) {
+	return _IUniswapV3Pool.Contract.Slot0(&_IUniswapV3Pool.CallOpts)
+}
+
+// SnapshotCumulativesInside is a free data retrieval call binding the contract method 0xa38807f2.
+//
+// Solidity: function snapshotCumulativesInside(int24 tickLower, int24 tickUpper) view returns(int56 tickCumulativeInside, uint160 secondsPerLiquidityInsideX128, uint32 secondsInside)
+func (_IUniswapV3Pool *IUniswapV3PoolCaller) SnapshotCumulativesInside(opts *bind.CallOpts, tickLower *big.Int, tickUpper *big.Int) (struct {
+	TickCumulativeInside          *big.Int
+	SecondsPerLiquidityInsideX128 *big.Int
+	SecondsInside                 uint32
+}, error) {
+	var out []interface{}
+	err := _IUniswapV3Pool.contract.Call(opts, &out, "snapshotCumulativesInside", tickLower, tickUpper)
+
+	outstruct := new(struct {
+		TickCumulativeInside          *big.Int
+		SecondsPerLiquidityInsideX128 *big.Int
+		SecondsInside                 uint32
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.TickCumulativeInside = *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+	outstruct.SecondsPerLiquidityInsideX128 = *abi.ConvertType(out[1], new(*big.Int)).(**big.Int)
+	outstruct.SecondsInside = *abi.ConvertType(out[2], new(uint32)).(*uint32)
+
+	return *outstruct, err
+
+}
+
+// SnapshotCumulativesInside is a free data retrieval call binding the contract method 0xa38807f2.
+//
+// Solidity: function snapshotCumulativesInside(int24 tickLower, int24 tickUpper) view returns(int56 tickCumulativeInside, uint160 secondsPerLiquidityInsideX128, uint32 secondsInside)
+func (_IUniswapV3Pool *IUniswapV3PoolSession) SnapshotCumulativesInside(tickLower *big.Int, tickUpper *big.Int) (struct {
+	TickCumulativeInside          *big.Int
+	SecondsPerLiquidityInsideX128 *big.Int
+	SecondsInside                 uint32
+}, error) {
+	return _IUniswapV3Pool.Contract.SnapshotCumulativesInside(&_IUniswapV3Pool.CallOpts, tickLower, tickUpper)
+}
+
+// SnapshotCumulativesInside is a free data retrieval call binding the contract method 0xa38807f2.
+//
+// Solidity: function snapshotCumulativesInside(int24 tickLower, int24 tickUpper) view returns(int56 tickCumulativeInside, uint160 secondsPerLiquidityInsideX128, uint32 secondsInside)
+func (_IUniswapV3Pool *IUniswapV3PoolCallerSession) SnapshotCumulativesInside(tickLower *big.Int, tickUpper *big.Int) (struct {
+	TickCumulativeInside          *big.Int
+	SecondsPerLiquidityInsideX128 *big.Int
+	SecondsInside                 uint32
+}, error) {
+	return _IUniswapV3Pool.Contract.SnapshotCumulativesInside(&_IUniswapV3Pool.CallOpts, tickLower, tickUpper)
+}
+
+// TickBitmap is a free data retrieval call binding the contract method 0x5339c296.
+//
+// Solidity: function tickBitmap(int16 wordPosition) view returns(uint256)
+func (_IUniswapV3Pool *IUniswapV3PoolCaller) TickBitmap(opts *bind.CallOpts, wordPosition int16) (*big.Int, error) {
+	var out []interface{}
+	err := _IUniswapV3Pool.contract.Call(opts, &out, "tickBitmap", wordPosition)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// TickBitmap is a free data retrieval call binding the contract method 0x5339c296.
+//
+// Solidity: function tickBitmap(int16 wordPosition) view returns(uint256)
+func (_IUniswapV3Pool *IUniswapV3PoolSession) TickBitmap(wordPosition int16) (*big.Int, error) {
+	return _IUniswapV3Pool.Contract.TickBitmap(&_IUniswapV3Pool.CallOpts, wordPosition)
+}
+
+// TickBitmap is a free data retrieval call binding the contract method 0x5339c296.
+//
+// Solidity: function tickBitmap(int16 wordPosition) view returns(uint256)
+func (_IUniswapV3Pool *IUniswapV3PoolCallerSession) TickBitmap(wordPosition int16) (*big.Int, error) {
+	return _IUniswapV3Pool.Contract.TickBitmap(&_IUniswapV3Pool.CallOpts, wordPosition)
+}
+
+// TickSpacing is a free data retrieval call binding the contract method 0xd0c93a7c.
+//
+// Solidity: function tickSpacing() view returns(int24)
+func (_IUniswapV3Pool *IUniswapV3PoolCaller) TickSpacing(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _IUniswapV3Pool.contract.Call(opts, &out, "tickSpacing")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// TickSpacing is a free data retrieval call binding the contract method 0xd0c93a7c.
+//
+// Solidity: function tickSpacing() view returns(int24)
+func (_IUniswapV3Pool *IUniswapV3PoolSession) TickSpacing() (*big.Int, error) {
+	return _IUniswapV3Pool.Contract.TickSpacing(&_IUniswapV3Pool.CallOpts)
+}
+
+// TickSpacing is a free data retrieval call binding the contract method 0xd0c93a7c.
+//
+// Solidity: function tickSpacing() view returns(int24)
+func (_IUniswapV3Pool *IUniswapV3PoolCallerSession) TickSpacing() (*big.Int, error) {
+	return _IUniswapV3Pool.Contract.TickSpacing(&_IUniswapV3Pool.CallOpts)
+}
+
+// Ticks is a free data retrieval call binding the contract method 0xf30dba93.
+//
+// Solidity: function ticks(int24 tick) view returns(uint128 liquidityGross, int128 liquidityNet, uint256 feeGrowthOutside0X128, uint256 feeGrowthOutside1X128, int56 tickCumulativeOutside, uint160 secondsPerLiquidityOutsideX128, uint32 secondsOutside, bool initialized)
+func (_IUniswapV3Pool *IUniswapV3PoolCaller) Ticks(opts *bind.CallOpts, tick *big.Int) (struct {
+	LiquidityGross                 *big.Int
+	LiquidityNet                   *big.Int
+	FeeGrowthOutside0X128          *big.Int
+	FeeGrowthOutside1X128          *big.Int
+	TickCumulativeOutside          *big.Int
+	SecondsPerLiquidityOutsideX128 *big.Int
+	SecondsOutside                 uint32
+	Initialized                    bool
+}, error) {
+	var out []interface{}
+	err := _IUniswapV3Pool.contract.Call(opts, &out, "ticks", tick)
+
+	outstruct := new(struct {
+		LiquidityGross                 *big.Int
+		LiquidityNet                   *big.Int
+		FeeGrowthOutside0X128          *big.Int
+		FeeGrowthOutside1X128          *big.Int
+		TickCumulativeOutside          *big.Int
+		SecondsPerLiquidityOutsideX128 *big.Int
+		SecondsOutside                 uint32
+		Initialized                    bool
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.LiquidityGross = *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+	outstruct.LiquidityNet = *abi.ConvertType(out[1], new(*big.Int)).(**big.Int)
+	outstruct.FeeGrowthOutside0X128 = *abi.ConvertType(out[2], new(*big.Int)).(**big.Int)
+	outstruct.FeeGrowthOutside1X128 = *abi.ConvertType(out[3], new(*big.Int)).(**big.Int)
+	outstruct.TickCumulativeOutside = *abi.ConvertType(out[4], new(*big.Int)).(**big.Int)
+	outstruct.SecondsPerLiquidityOutsideX128 = *abi.ConvertType(out[5], new(*big.Int)).(**big.Int)
+	outstruct.SecondsOutside = *abi.ConvertType(out[6], new(uint32)).(*uint32)
+	outstruct.Initialized = *abi.ConvertType(out[7], new(bool)).(*bool)
+
+	return *outstruct, err
+
+}
+
+// Ticks is a free data retrieval call binding the contract method 0xf30dba93.
+//
+// Solidity: function ticks(int24 tick) view returns(uint128 liquidityGross, int128 liquidityNet, uint256 feeGrowthOutside0X128, uint256 feeGrowthOutside1X128, int56 tickCumulativeOutside, uint160 secondsPerLiquidityOutsideX128, uint32 secondsOutside, bool initialized)
+func (_IUniswapV3Pool *IUniswapV3PoolSession) Ticks(tick *big.Int) (struct {
+	LiquidityGross                 *big.Int
+	LiquidityNet                   *big.Int
+	FeeGrowthOutside0X128          *big.Int
+	FeeGrowthOutside1X128          *big.Int
+	TickCumulativeOutside          *big.Int
+	SecondsPerLiquidityOutsideX128 *big.Int
+	SecondsOutside                 uint32
+	Initialized                    bool
+}, error) {
+	return _IUniswapV3Pool.Contract.Ticks(&_IUniswapV3Pool.CallOpts, tick)
+}
+
+// Ticks is a free data retrieval call binding the contract method 0xf30dba93.
+//
+// Solidity: function ticks(int24 tick) view returns(uint128 liquidityGross, int128 liquidityNet, uint256 feeGrowthOutside0X128, uint256 feeGrowthOutside1X128, int56 tickCumulativeOutside, uint160 secondsPerLiquidityOutsideX128, uint32 secondsOutside, bool initialized)
+func (_IUniswapV3Pool *IUniswapV3PoolCallerSession) Ticks(tick *big.Int) (struct {
+	LiquidityGross                 *big.Int
+	LiquidityNet                   *big.Int
+	FeeGrowthOutside0X128          *big.Int
+	FeeGrowthOutside1X128          *big.Int
+	TickCumulativeOutside          *big.Int
+	SecondsPerLiquidityOutsideX128 *big.Int
+	SecondsOutside                 uint32
+	Initialized                    bool
+}, error) {
+	return _IUniswapV3Pool.Contract.Ticks(&_IUniswapV3Pool.CallOpts, tick)
+}
+
+// Token0 is a free data retrieval call binding the contract method 0x0dfe1681.
+//
+// Solidity: function token0() view returns(address)
+func (_IUniswapV3Pool *IUniswapV3PoolCaller) Token0(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _IUniswapV3Pool.contract.Call(opts, &out, "token0")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Token0 is a free data retrieval call binding the contract method 0x0dfe1681.
+//
+// Solidity: function token0() view returns(address)
+func (_IUniswapV3Pool *IUniswapV3PoolSession) Token0() (common.Address, error) {
+	return _IUniswapV3Pool.Contract.Token0(&_IUniswapV3Pool.CallOpts)
+}
+
+// Token0 is a free data retrieval call binding the contract method 0x0dfe1681.
+//
+// Solidity: function token0() view returns(address)
+func (_IUniswapV3Pool *IUniswapV3PoolCallerSession) Token0() (common.Address, error) {
+	return _IUniswapV3Pool.Contract.Token0(&_IUniswapV3Pool.CallOpts)
+}
+
+// Token1 is a free data retrieval call binding the contract method 0xd21220a7.
+//
+// Solidity: function token1() view returns(address)
+func (_IUniswapV3Pool *IUniswapV3PoolCaller) Token1(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _IUniswapV3Pool.contract.Call(opts, &out, "token1")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Token1 is a free data retrieval call binding the contract method 0xd21220a7.
+//
+// Solidity: function token1() view returns(address)
+func (_IUniswapV3Pool *IUniswapV3PoolSession) Token1() (common.Address, error) {
+	return _IUniswapV3Pool.Contract.Token1(&_IUniswapV3Pool.CallOpts)
+}
+
+// Token1 is a free data retrieval call binding the contract method 0xd21220a7.
+//
+// Solidity: function token1() view returns(address)
+func (_IUniswapV3Pool *IUniswapV3PoolCallerSession) Token1() (common.Address, error) {
+	return _IUniswapV3Pool.Contract.Token1(&_IUniswapV3Pool.CallOpts)
+}
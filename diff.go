@@ -0,0 +1,197 @@
+package go_eth_multicall
+
+import (
+	"bytes"
+	"math/big"
+	"reflect"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+// ChangeKind tells how a call differs between two result sets
+type ChangeKind int
+
+const (
+	// ChangeAdded is a call only present in the newer result set
+	ChangeAdded ChangeKind = iota
+	// ChangeRemoved is a call only present in the older result set
+	ChangeRemoved
+	// ChangeModified is a call present in both result sets whose response differs
+	ChangeModified
+)
+
+func (kind ChangeKind) String() string {
+	switch kind {
+	case ChangeAdded:
+		return "added"
+	case ChangeRemoved:
+		return "removed"
+	case ChangeModified:
+		return "modified"
+	}
+
+	return "unknown"
+}
+
+// NumericDelta is the difference between the old and new value of a numeric output
+type NumericDelta struct {
+	// Output is the name of the output, or its position when it is unnamed
+	Output string
+	Old    *big.Int
+	New    *big.Int
+	// Delta is New - Old
+	Delta *big.Int
+}
+
+// ResultChange describes one call that differs between two result sets
+type ResultChange struct {
+	Kind ChangeKind
+	// Key is the call name when diffing by name, or the call index when diffing by index
+	Key  string
+	Call Call
+	// Old is nil for added calls, New is nil for removed calls
+	Old *CallResponse
+	New *CallResponse
+	// SuccessChanged is set when the call started or stopped reverting
+	SuccessChanged bool
+	// Deltas holds the numeric outputs that changed, when the call carries Outputs and both responses decode
+	Deltas []NumericDelta
+}
+
+// ResultDiff is the list of calls that differ between two result sets
+type ResultDiff struct {
+	FromBlock uint64
+	ToBlock   uint64
+	Changes   []ResultChange
+}
+
+// Empty reports whether the result sets hold the same responses
+func (diff ResultDiff) Empty() bool {
+	return len(diff.Changes) == 0
+}
+
+// DiffResultsByName compares two result sets, matching calls by name. Calls without a response are left out, as if
+// they were not in their result set.
+func DiffResultsByName(from, to ResultSet) ResultDiff {
+	diff := ResultDiff{FromBlock: from.BlockNumber, ToBlock: to.BlockNumber}
+	fromCalls, toCalls := from.answered(), to.answered()
+
+	fromIndexes := make(map[string]int, len(fromCalls))
+	for i, call := range fromCalls {
+		fromIndexes[call.Name] = i
+	}
+	toNames := make(map[string]bool, len(toCalls))
+
+	for i, call := range toCalls {
+		toNames[call.Name] = true
+
+		j, ok := fromIndexes[call.Name]
+		if !ok {
+			diff.Changes = append(diff.Changes, ResultChange{Kind: ChangeAdded, Key: call.Name, Call: call, New: &to.Responses[i]})
+			continue
+		}
+		if change, changed := compareResponses(call.Name, fromCalls[j], call, &from.Responses[j], &to.Responses[i]); changed {
+			diff.Changes = append(diff.Changes, change)
+		}
+	}
+
+	for i, call := range fromCalls {
+		if !toNames[call.Name] {
+			diff.Changes = append(diff.Changes, ResultChange{Kind: ChangeRemoved, Key: call.Name, Call: call, Old: &from.Responses[i]})
+		}
+	}
+
+	return diff
+}
+
+// DiffResultsByIndex compares two result sets, matching calls by position. Calls without a response are left out,
+// as if they were not in their result set.
+func DiffResultsByIndex(from, to ResultSet) ResultDiff {
+	diff := ResultDiff{FromBlock: from.BlockNumber, ToBlock: to.BlockNumber}
+	fromCalls, toCalls := from.answered(), to.answered()
+
+	for i, call := range toCalls {
+		key := strconv.Itoa(i)
+		if i >= len(fromCalls) {
+			diff.Changes = append(diff.Changes, ResultChange{Kind: ChangeAdded, Key: key, Call: call, New: &to.Responses[i]})
+			continue
+		}
+		if change, changed := compareResponses(key, fromCalls[i], call, &from.Responses[i], &to.Responses[i]); changed {
+			diff.Changes = append(diff.Changes, change)
+		}
+	}
+
+	for i := len(toCalls); i < len(fromCalls); i++ {
+		diff.Changes = append(diff.Changes, ResultChange{Kind: ChangeRemoved, Key: strconv.Itoa(i), Call: fromCalls[i], Old: &from.Responses[i]})
+	}
+
+	return diff
+}
+
+func compareResponses(key string, fromCall, toCall Call, from, to *CallResponse) (ResultChange, bool) {
+	if from.Success == to.Success && bytes.Equal(from.ReturnData, to.ReturnData) {
+		return ResultChange{}, false
+	}
+
+	change := ResultChange{Kind: ChangeModified, Key: key, Call: toCall, Old: from, New: to, SuccessChanged: from.Success != to.Success}
+
+	outputs := toCall.Outputs
+	if len(outputs) == 0 {
+		outputs = fromCall.Outputs
+	}
+	if len(outputs) > 0 && from.Success && to.Success {
+		change.Deltas = numericDeltas(outputs, from.ReturnData, to.ReturnData)
+	}
+
+	return change, true
+}
+
+// numericDeltas decodes both return datas and reports the integer outputs whose value changed
+func numericDeltas(outputs abi.Arguments, oldData, newData []byte) []NumericDelta {
+	oldValues, err := outputs.Unpack(oldData)
+	if err != nil {
+		return nil
+	}
+	newValues, err := outputs.Unpack(newData)
+	if err != nil {
+		return nil
+	}
+
+	var deltas []NumericDelta
+	for i := range outputs {
+		oldValue, ok := toBigInt(oldValues[i])
+		if !ok {
+			continue
+		}
+		newValue, ok := toBigInt(newValues[i])
+		if !ok || oldValue.Cmp(newValue) == 0 {
+			continue
+		}
+
+		name := outputs[i].Name
+		if name == "" {
+			name = strconv.Itoa(i)
+		}
+		deltas = append(deltas, NumericDelta{Output: name, Old: oldValue, New: newValue, Delta: new(big.Int).Sub(newValue, oldValue)})
+	}
+
+	return deltas
+}
+
+// toBigInt converts the decoded value of an integer ABI type to a big.Int
+func toBigInt(value interface{}) (*big.Int, bool) {
+	if value, ok := value.(*big.Int); ok {
+		return value, true
+	}
+
+	reflected := reflect.ValueOf(value)
+	switch reflected.Kind() {
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return big.NewInt(reflected.Int()), true
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return new(big.Int).SetUint64(reflected.Uint()), true
+	}
+
+	return nil, false
+}
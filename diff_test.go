@@ -0,0 +1,61 @@
+package go_eth_multicall
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func word(value int64) []byte {
+	return common.BigToHash(big.NewInt(value)).Bytes()
+}
+
+func TestDiffResultsByName(t *testing.T) {
+	from := ResultSet{
+		BlockNumber: 1,
+		Calls:       []Call{{Name: "a"}, {Name: "b"}, {Name: "c"}},
+		Responses:   []CallResponse{{Success: true, ReturnData: word(1)}, {Success: true, ReturnData: word(2)}, {Success: true, ReturnData: word(3)}},
+	}
+	to := ResultSet{
+		BlockNumber: 2,
+		Calls:       []Call{{Name: "a"}, {Name: "b"}, {Name: "d"}},
+		Responses:   []CallResponse{{Success: true, ReturnData: word(1)}, {Success: false}, {Success: true, ReturnData: word(4)}},
+	}
+
+	diff := DiffResultsByName(from, to)
+	want := map[string]ChangeKind{"b": ChangeModified, "d": ChangeAdded, "c": ChangeRemoved}
+	if len(diff.Changes) != len(want) {
+		t.Fatalf("got %d changes, want %d", len(diff.Changes), len(want))
+	}
+	for _, change := range diff.Changes {
+		if kind, ok := want[change.Key]; !ok || kind != change.Kind {
+			t.Errorf("got %s %s", change.Kind, change.Key)
+		}
+		if change.Key == "b" && !change.SuccessChanged {
+			t.Error("b stopped succeeding")
+		}
+	}
+}
+
+func TestDiffResultsMissingResponses(t *testing.T) {
+	from := ResultSet{
+		Calls:     []Call{{Name: "a"}, {Name: "b"}},
+		Responses: []CallResponse{{Success: true, ReturnData: word(1)}, {Success: true, ReturnData: word(2)}},
+	}
+	// The second call has no response, it must be reported removed rather than panic
+	to := ResultSet{
+		Calls:     []Call{{Name: "a"}, {Name: "b"}},
+		Responses: []CallResponse{{Success: true, ReturnData: word(1)}},
+	}
+
+	for name, diff := range map[string]ResultDiff{"name": DiffResultsByName(from, to), "index": DiffResultsByIndex(from, to)} {
+		if len(diff.Changes) != 1 || diff.Changes[0].Kind != ChangeRemoved || diff.Changes[0].Call.Name != "b" {
+			t.Errorf("by %s: got %+v, want b removed", name, diff.Changes)
+		}
+	}
+
+	if responses := to.Map(); len(responses) != 1 {
+		t.Errorf("got %d responses, want 1", len(responses))
+	}
+}
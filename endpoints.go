@@ -0,0 +1,398 @@
+package go_eth_multicall
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	DefaultHealthCheckInterval = 10 * time.Second
+	DefaultMaxHeadLag          = 5
+	DefaultMaxErrorRate        = 0.5
+)
+
+// errorRateSmoothing is the weight of the latest outcome in the error rate of an endpoint
+const errorRateSmoothing = 0.1
+
+// Endpoint is one of the RPC providers of an EndpointPool
+type Endpoint struct {
+	URL string
+	// Name identifies the endpoint in reports without exposing the keys URLs often carry, the host of URL when empty
+	Name string
+	// Weight is the share of the executions routed to the endpoint among the healthy ones, 1 when zero
+	Weight int
+	// Limiter optionally keeps the requests to the endpoint under its rate limits, health checks included. The
+	// executions routed to an endpoint without one use the Limiter of the caller.
+	Limiter *Limiter
+}
+
+// PoolOptions tells how often endpoints are checked and when they are considered unhealthy
+type PoolOptions struct {
+	// CheckInterval is the time between two health checks, DefaultHealthCheckInterval when zero
+	CheckInterval time.Duration
+	// MaxHeadLag ejects the endpoints whose head is more than this many blocks behind the highest head observed,
+	// DefaultMaxHeadLag when zero
+	MaxHeadLag uint64
+	// MaxErrorRate ejects the endpoints whose recent requests fail more often than this, DefaultMaxErrorRate when zero
+	MaxErrorRate float64
+}
+
+// EndpointStatus is the health of an endpoint as of its last check
+type EndpointStatus struct {
+	Name      string
+	URL       string
+	Weight    int
+	Healthy   bool
+	Latency   time.Duration
+	Head      uint64
+	ErrorRate float64
+	LastError error
+}
+
+type poolEndpoint struct {
+	Endpoint
+	client    *ethclient.Client
+	rpcClient *rpc.Client
+
+	// Guarded by the mutex of the pool
+	checked   bool
+	reachable bool
+	latency   time.Duration
+	head      uint64
+	errorRate float64
+	lastError error
+}
+
+// EndpointPool spreads the executions of an EthMultiCaller over several RPC endpoints. The endpoints are checked
+// in the background for latency, head lag and error rate, executions are routed to a healthy endpoint picked at
+// random according to the weights, and fail over to the next one when a request fails for a reason another
+// endpoint may not have.
+type EndpointPool struct {
+	options   PoolOptions
+	endpoints []*poolEndpoint
+	stop      context.CancelFunc
+	done      chan struct{}
+
+	mu          sync.Mutex
+	highestHead uint64
+}
+
+// NewEndpointPool dials the endpoints and starts checking them in the background until Close is called
+func NewEndpointPool(endpoints []Endpoint, options PoolOptions) (*EndpointPool, error) {
+	if len(endpoints) == 0 {
+		return nil, errors.New("multicall: no endpoints")
+	}
+	if options.CheckInterval <= 0 {
+		options.CheckInterval = DefaultHealthCheckInterval
+	}
+	if options.MaxHeadLag == 0 {
+		options.MaxHeadLag = DefaultMaxHeadLag
+	}
+	if options.MaxErrorRate <= 0 {
+		options.MaxErrorRate = DefaultMaxErrorRate
+	}
+
+	pool := &EndpointPool{options: options, done: make(chan struct{})}
+	for _, endpoint := range endpoints {
+		if endpoint.Weight <= 0 {
+			endpoint.Weight = 1
+		}
+		if endpoint.Name == "" {
+			if parsed, err := url.Parse(endpoint.URL); err == nil && parsed.Host != "" {
+				endpoint.Name = parsed.Host
+			} else {
+				endpoint.Name = endpoint.URL
+			}
+		}
+
+		rpcClient, err := rpc.Dial(endpoint.URL)
+		if err != nil {
+			pool.closeClients()
+			return nil, err
+		}
+		pool.endpoints = append(pool.endpoints, &poolEndpoint{Endpoint: endpoint, client: ethclient.NewClient(rpcClient), rpcClient: rpcClient})
+	}
+
+	ctx, stop := context.WithCancel(context.Background())
+	pool.stop = stop
+	go pool.checkLoop(ctx)
+
+	return pool, nil
+}
+
+// NewWithEndpoints is New over a pool of endpoints
+func NewWithEndpoints(endpoints []Endpoint, multilcalContractAddress string, options PoolOptions) EthMultiCaller {
+	pool, err := NewEndpointPool(endpoints, options)
+	if err != nil {
+		panic(err)
+	}
+
+	caller := newCaller(pool.endpoints[0].rpcClient, multilcalContractAddress)
+	caller.Endpoints = pool
+
+	return caller
+}
+
+// Close stops the health checks and closes the connections
+func (pool *EndpointPool) Close() {
+	pool.stop()
+	<-pool.done
+	pool.closeClients()
+}
+
+func (pool *EndpointPool) closeClients() {
+	for _, endpoint := range pool.endpoints {
+		endpoint.rpcClient.Close()
+	}
+}
+
+func (pool *EndpointPool) checkLoop(ctx context.Context) {
+	defer close(pool.done)
+
+	ticker := time.NewTicker(pool.options.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		pool.Check(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Check fetches the latest header of every endpoint, updating their latency, head and error rate
+func (pool *EndpointPool) Check(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, pool.options.CheckInterval)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for _, endpoint := range pool.endpoints {
+		wg.Add(1)
+		go func(endpoint *poolEndpoint) {
+			defer wg.Done()
+
+			// A check the limiter does not admit is skipped, it would not tell anything about the endpoint
+			if endpoint.Limiter.Wait(ctx, "eth_getBlockByNumber", 1) != nil {
+				return
+			}
+
+			start := time.Now()
+			header, err := endpoint.client.HeaderByNumber(ctx, nil)
+			latency := time.Since(start)
+			if ctx.Err() != nil && errors.Is(err, context.Canceled) {
+				// The pool is closing
+				return
+			}
+
+			pool.mu.Lock()
+			defer pool.mu.Unlock()
+
+			endpoint.checked = true
+			endpoint.reachable = err == nil
+			pool.recordLocked(endpoint, err)
+			if err != nil {
+				return
+			}
+
+			if endpoint.latency == 0 {
+				endpoint.latency = latency
+			} else {
+				endpoint.latency = (endpoint.latency*3 + latency) / 4
+			}
+			endpoint.head = header.Number.Uint64()
+			if endpoint.head > pool.highestHead {
+				pool.highestHead = endpoint.head
+			}
+		}(endpoint)
+	}
+	wg.Wait()
+}
+
+func (pool *EndpointPool) record(endpoint *poolEndpoint, err error) {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	pool.recordLocked(endpoint, err)
+}
+
+func (pool *EndpointPool) recordLocked(endpoint *poolEndpoint, err error) {
+	outcome := 0.0
+	if err != nil {
+		outcome = 1
+		endpoint.lastError = err
+	}
+	endpoint.errorRate = endpoint.errorRate*(1-errorRateSmoothing) + outcome*errorRateSmoothing
+}
+
+// healthyLocked reports whether an endpoint can be routed to. Endpoints that were not checked yet are healthy.
+func (pool *EndpointPool) healthyLocked(endpoint *poolEndpoint) bool {
+	if endpoint.checked && !endpoint.reachable {
+		return false
+	}
+	if endpoint.errorRate > pool.options.MaxErrorRate {
+		return false
+	}
+
+	return !endpoint.checked || endpoint.head+pool.options.MaxHeadLag >= pool.highestHead
+}
+
+// Status returns the health of every endpoint
+func (pool *EndpointPool) Status() []EndpointStatus {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	statuses := make([]EndpointStatus, len(pool.endpoints))
+	for i, endpoint := range pool.endpoints {
+		statuses[i] = EndpointStatus{
+			Name:      endpoint.Name,
+			URL:       endpoint.URL,
+			Weight:    endpoint.Weight,
+			Healthy:   pool.healthyLocked(endpoint),
+			Latency:   endpoint.latency,
+			Head:      endpoint.head,
+			ErrorRate: endpoint.errorRate,
+			LastError: endpoint.lastError,
+		}
+	}
+
+	return statuses
+}
+
+// order returns the endpoints in the order an execution tries them: the healthy ones shuffled according to their
+// weights, then the unhealthy ones from the least to the most failing
+func (pool *EndpointPool) order() []*poolEndpoint {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	var healthy, unhealthy []*poolEndpoint
+	totalWeight := 0
+	for _, endpoint := range pool.endpoints {
+		if pool.healthyLocked(endpoint) {
+			healthy = append(healthy, endpoint)
+			totalWeight += endpoint.Weight
+		} else {
+			unhealthy = append(unhealthy, endpoint)
+		}
+	}
+
+	ordered := make([]*poolEndpoint, 0, len(pool.endpoints))
+	for len(healthy) > 0 {
+		pick := rand.Intn(totalWeight)
+		for i, endpoint := range healthy {
+			if pick < endpoint.Weight {
+				ordered = append(ordered, endpoint)
+				totalWeight -= endpoint.Weight
+				healthy = append(healthy[:i], healthy[i+1:]...)
+				break
+			}
+			pick -= endpoint.Weight
+		}
+	}
+
+	sort.SliceStable(unhealthy, func(i, j int) bool { return unhealthy[i].errorRate < unhealthy[j].errorRate })
+	return append(ordered, unhealthy...)
+}
+
+// failover runs do on each endpoint in turn until it succeeds or fails for a reason that does not depend on the
+// endpoint, like a revert. Only failures of the endpoint count against its health.
+func (pool *EndpointPool) failover(ctx context.Context, do func(ctx context.Context) error) error {
+	var err error
+	for i, endpoint := range pool.order() {
+		if i > 0 {
+			addRetry(ctx)
+		}
+
+		err = do(context.WithValue(ctx, endpointKey{}, endpoint))
+		if ctx.Err() != nil {
+			return err
+		}
+		if err == nil {
+			pool.record(endpoint, nil)
+			return nil
+		}
+
+		if errors.Is(err, ErrRateLimited) {
+			// Refused by our own limiter, the endpoint is fine
+			continue
+		}
+		if !endpointFault(err) {
+			// Another endpoint would fail the same way, e.g. on a revert or a malformed request
+			return err
+		}
+		pool.record(endpoint, err)
+	}
+
+	return err
+}
+
+// endpointFault reports whether a failure is specific to the endpoint, so another one may succeed: the transport
+// failed or the node is overloaded, lags behind the chain or lacks the state of the block
+func endpointFault(err error) bool {
+	switch ClassifyError(err) {
+	case ErrorRateLimited, ErrorTimeout, ErrorConnection, ErrorUnavailable, ErrorStatePruned:
+		return true
+	}
+	if errors.Is(err, ethereum.NotFound) {
+		return true
+	}
+
+	message := strings.ToLower(err.Error())
+	return strings.Contains(message, "header not found") || strings.Contains(message, "unknown block")
+}
+
+type endpointKey struct{}
+
+func endpointFrom(ctx context.Context) *poolEndpoint {
+	endpoint, _ := ctx.Value(endpointKey{}).(*poolEndpoint)
+	return endpoint
+}
+
+// route runs do against the endpoint pool when there is one, failing over between endpoints and retrying the
+// whole pool according to the retry policy. Nested routes stay on the endpoint picked by the outer one.
+func (caller *EthMultiCaller) route(ctx context.Context, do func(ctx context.Context) error) error {
+	if caller.Endpoints == nil || endpointFrom(ctx) != nil {
+		return do(ctx)
+	}
+
+	return caller.withRetry(ctx, func() error {
+		return caller.Endpoints.failover(ctx, do)
+	})
+}
+
+// client returns the client of the endpoint the execution was routed to, the preferred healthy endpoint when it
+// was not routed, or Client without an endpoint pool
+func (caller *EthMultiCaller) client(ctx context.Context) *ethclient.Client {
+	if endpoint := endpointFrom(ctx); endpoint != nil {
+		return endpoint.client
+	}
+	if caller.Endpoints != nil {
+		return caller.Endpoints.order()[0].client
+	}
+
+	return caller.Client
+}
+
+// rpcClient is client for the JSON-RPC connection, it may be nil without an endpoint pool
+func (caller *EthMultiCaller) rpcClient(ctx context.Context) *rpc.Client {
+	if endpoint := endpointFrom(ctx); endpoint != nil {
+		return endpoint.rpcClient
+	}
+	if caller.Endpoints != nil {
+		return caller.Endpoints.order()[0].rpcClient
+	}
+
+	return caller.RPCClient
+}
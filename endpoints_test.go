@@ -0,0 +1,49 @@
+package go_eth_multicall
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+func TestFailover(t *testing.T) {
+	tests := []struct {
+		err       error
+		attempts  int
+		penalized bool
+	}{
+		{err: errors.New("header not found"), attempts: 2, penalized: true},
+		{err: rpc.HTTPError{StatusCode: 503, Status: "503 Service Unavailable"}, attempts: 2, penalized: true},
+		{err: errors.New("too many requests"), attempts: 2, penalized: true},
+		{err: errors.New("execution reverted"), attempts: 1},
+		{err: errors.New("out of gas"), attempts: 1},
+		{err: errors.New("abi: attempting to unmarshall an empty string while arguments are expected"), attempts: 1},
+	}
+
+	for _, test := range tests {
+		_, caller := newFakePool(t, "a", "b")
+		pool := caller.Endpoints
+
+		// The first endpoint tried fails, the next one succeeds
+		var tried []*poolEndpoint
+		err := pool.failover(context.Background(), func(ctx context.Context) error {
+			tried = append(tried, endpointFrom(ctx))
+			if len(tried) == 1 {
+				return test.err
+			}
+			return nil
+		})
+
+		if len(tried) != test.attempts {
+			t.Errorf("%v: tried %d endpoints, want %d", test.err, len(tried), test.attempts)
+		}
+		if test.attempts == 1 && err != test.err {
+			t.Errorf("%v: got %v", test.err, err)
+		}
+		if penalized := tried[0].errorRate > 0; penalized != test.penalized {
+			t.Errorf("%v: got penalized %v, want %v", test.err, penalized, test.penalized)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+// Package ens resolves ENS names for many addresses at once through an EthMultiCaller.
+package ens
+
+import (
+	"encoding/hex"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	multicall "github.com/truongpx396/go-eth-multicall"
+	"github.com/truongpx396/go-eth-multicall/contracts/ENSRegistry"
+	"github.com/truongpx396/go-eth-multicall/contracts/ENSResolver"
+)
+
+// RegistryAddress is the address of the ENS registry on mainnet and its testnets
+var RegistryAddress = common.HexToAddress("0x00000000000C2E074eC69A0dFb2997BA6C7d2e1e")
+
+var (
+	registryAbi = multicall.MustParseABI(ENSRegistry.ENSRegistryABI)
+	resolverAbi = multicall.MustParseABI(ENSResolver.ENSResolverABI)
+)
+
+// Namehash returns the node of a name as defined in EIP-137
+func Namehash(name string) common.Hash {
+	var node common.Hash
+	if name == "" {
+		return node
+	}
+
+	labels := strings.Split(name, ".")
+	for i := len(labels) - 1; i >= 0; i-- {
+		node = crypto.Keccak256Hash(node.Bytes(), crypto.Keccak256([]byte(labels[i])))
+	}
+
+	return node
+}
+
+// ReverseNode returns the node of the reverse record of an address, <address>.addr.reverse
+func ReverseNode(address common.Address) common.Hash {
+	return Namehash(hex.EncodeToString(address.Bytes()) + ".addr.reverse")
+}
+
+// unpack decodes the single value returned by a call made with multicall.NewCall
+func unpack(contractAbi abi.ABI, responses map[string]multicall.CallResponse, target common.Address, method string, node common.Hash) (interface{}, bool) {
+	values, err := multicall.Unpack(contractAbi, responses, target, method, node)
+	if err != nil {
+		return nil, false
+	}
+
+	return values[0], true
+}
+
+// resolvers looks up the resolver of every node in the registry, nodes without a resolver are left out
+func resolvers(caller *multicall.EthMultiCaller, registry common.Address, nodes []common.Hash, blockNumber *big.Int) map[common.Hash]common.Address {
+	calls := make([]multicall.Call, 0, len(nodes))
+	for _, node := range nodes {
+		calls = append(calls, multicall.NewCall(registryAbi, registry, "resolver", node))
+	}
+
+	responses := caller.ExecuteAtBlock(calls, blockNumber)
+
+	found := make(map[common.Hash]common.Address)
+	for _, node := range nodes {
+		if resolver, ok := unpack(registryAbi, responses, registry, "resolver", node); ok && resolver.(common.Address) != (common.Address{}) {
+			found[node] = resolver.(common.Address)
+		}
+	}
+
+	return found
+}
+
+// LookupAddresses returns the primary ENS name of every address that has one.
+//
+// A name is only returned when it resolves back to the same address, so names claimed through a reverse
+// record the address does not control are dropped. The lookup takes four dependent multicalls (reverse
+// resolvers, names, forward resolvers, addresses), all pinned to the block of the first one. An error is
+// returned when the block number call of the first multicall failed.
+func LookupAddresses(caller *multicall.EthMultiCaller, registry common.Address, addresses []common.Address) (map[common.Address]string, error) {
+	const blockNumberName = "blockNumber"
+
+	names := make(map[common.Address]string)
+
+	// Reverse resolvers, along with the block every following round is pinned to
+	calls := []multicall.Call{caller.GetBlockNumberCall(blockNumberName)}
+	for _, address := range addresses {
+		calls = append(calls, multicall.NewCall(registryAbi, registry, "resolver", ReverseNode(address)))
+	}
+
+	responses := caller.Execute(calls)
+	blockNumber, err := multicall.UnpackBlockNumber(responses, blockNumberName)
+	if err != nil {
+		return nil, err
+	}
+
+	// Names from the reverse records
+	calls = calls[:0]
+	reverseResolvers := make(map[common.Address]common.Address)
+	for _, address := range addresses {
+		node := ReverseNode(address)
+		if resolver, ok := unpack(registryAbi, responses, registry, "resolver", node); ok && resolver.(common.Address) != (common.Address{}) {
+			reverseResolvers[address] = resolver.(common.Address)
+			calls = append(calls, multicall.NewCall(resolverAbi, resolver.(common.Address), "name", node))
+		}
+	}
+	if len(calls) == 0 {
+		return names, nil
+	}
+
+	responses = caller.ExecuteAtBlock(calls, blockNumber)
+
+	claimed := make(map[common.Address]string)
+	var forwardNodes []common.Hash
+	seen := make(map[common.Hash]bool)
+	for address, resolver := range reverseResolvers {
+		name, ok := unpack(resolverAbi, responses, resolver, "name", ReverseNode(address))
+		if !ok || name.(string) == "" {
+			continue
+		}
+
+		claimed[address] = name.(string)
+		if node := Namehash(name.(string)); !seen[node] {
+			seen[node] = true
+			forwardNodes = append(forwardNodes, node)
+		}
+	}
+	if len(forwardNodes) == 0 {
+		return names, nil
+	}
+
+	// Forward resolution of the claimed names
+	forwardResolvers := resolvers(caller, registry, forwardNodes, blockNumber)
+	if len(forwardResolvers) == 0 {
+		return names, nil
+	}
+
+	calls = calls[:0]
+	for node, resolver := range forwardResolvers {
+		calls = append(calls, multicall.NewCall(resolverAbi, resolver, "addr", node))
+	}
+
+	responses = caller.ExecuteAtBlock(calls, blockNumber)
+
+	for address, name := range claimed {
+		node := Namehash(name)
+		resolver, ok := forwardResolvers[node]
+		if !ok {
+			continue
+		}
+
+		if resolved, ok := unpack(resolverAbi, responses, resolver, "addr", node); ok && resolved.(common.Address) == address {
+			names[address] = name
+		}
+	}
+
+	return names, nil
+}
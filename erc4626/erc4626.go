@@ -0,0 +1,176 @@
+// Package erc4626 reads ERC4626 vault state and holder positions through an EthMultiCaller.
+package erc4626
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	multicall "github.com/truongpx396/go-eth-multicall"
+	"github.com/truongpx396/go-eth-multicall/contracts/IERC4626"
+)
+
+var vaultAbi = multicall.MustParseABI(IERC4626.IERC4626ABI)
+
+// Position is a holder's balance in a vault, expressed in shares and in units of the underlying asset
+type Position struct {
+	Holder common.Address
+	Shares *big.Int
+	// Assets is convertToAssets(Shares), the holder's share of the vault ignoring fees and limits
+	Assets *big.Int
+	// RedeemableAssets is previewRedeem(Shares), what redeeming all shares would return right now
+	RedeemableAssets *big.Int
+}
+
+// Vault is the state of a single vault together with the positions of the requested holders
+type Vault struct {
+	Address     common.Address
+	Asset       common.Address
+	Decimals    uint8
+	TotalAssets *big.Int
+	TotalSupply *big.Int
+	// AssetsPerShare is convertToAssets of one whole share (10^Decimals), as computed by the vault
+	AssetsPerShare *big.Int
+	// PricePerShare is TotalAssets / TotalSupply in raw units, kept as an exact fraction.
+	// It is nil when the vault has no supply
+	PricePerShare *big.Rat
+	Positions     []Position
+	// Err is set when the vault did not answer the ERC4626 calls, in which case the other fields are incomplete
+	Err error
+}
+
+// Snapshot is the state of all requested vaults, read at a single block
+type Snapshot struct {
+	BlockNumber uint64
+	Vaults      []Vault
+}
+
+// oneShare is one whole share in raw units
+func oneShare(decimals uint8) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+}
+
+func unpackBig(responses map[string]multicall.CallResponse, vault common.Address, method string, args ...interface{}) (*big.Int, error) {
+	values, err := multicall.Unpack(vaultAbi, responses, vault, method, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return values[0].(*big.Int), nil
+}
+
+// Read fetches the state of every vault and the position of every holder in each vault.
+//
+// It runs two multicalls: the first reads the vault totals and holder balances, the second converts those
+// balances to assets. The second one is pinned to the block the first one was executed in, so every value
+// in the snapshot comes from the same block. An error is returned when the block number call of the first
+// multicall failed.
+func Read(caller *multicall.EthMultiCaller, vaults []common.Address, holders []common.Address) (Snapshot, error) {
+	const blockNumberName = "blockNumber"
+
+	calls := []multicall.Call{caller.GetBlockNumberCall(blockNumberName)}
+	for _, vault := range vaults {
+		calls = append(calls,
+			multicall.NewCall(vaultAbi, vault, "asset"),
+			multicall.NewCall(vaultAbi, vault, "decimals"),
+			multicall.NewCall(vaultAbi, vault, "totalAssets"),
+			multicall.NewCall(vaultAbi, vault, "totalSupply"),
+		)
+		for _, holder := range holders {
+			calls = append(calls, multicall.NewCall(vaultAbi, vault, "balanceOf", holder))
+		}
+	}
+
+	responses := caller.Execute(calls)
+
+	blockNumber, err := multicall.UnpackBlockNumber(responses, blockNumberName)
+	if err != nil {
+		return Snapshot{}, err
+	}
+	snapshot := Snapshot{BlockNumber: blockNumber.Uint64(), Vaults: make([]Vault, len(vaults))}
+
+	// Decode the totals and balances, then queue the conversions that depend on them
+	var conversions []multicall.Call
+	for i, vault := range vaults {
+		state := &snapshot.Vaults[i]
+		state.Address = vault
+
+		asset, err := multicall.Unpack(vaultAbi, responses, vault, "asset")
+		if err != nil {
+			state.Err = err
+			continue
+		}
+		decimals, err := multicall.Unpack(vaultAbi, responses, vault, "decimals")
+		if err != nil {
+			state.Err = err
+			continue
+		}
+		if state.TotalAssets, err = unpackBig(responses, vault, "totalAssets"); err != nil {
+			state.Err = err
+			continue
+		}
+		if state.TotalSupply, err = unpackBig(responses, vault, "totalSupply"); err != nil {
+			state.Err = err
+			continue
+		}
+		state.Asset = asset[0].(common.Address)
+		state.Decimals = decimals[0].(uint8)
+		if state.TotalSupply.Sign() > 0 {
+			state.PricePerShare = new(big.Rat).SetFrac(state.TotalAssets, state.TotalSupply)
+		}
+
+		conversions = append(conversions, multicall.NewCall(vaultAbi, vault, "convertToAssets", oneShare(state.Decimals)))
+
+		for _, holder := range holders {
+			shares, err := unpackBig(responses, vault, "balanceOf", holder)
+			if err != nil {
+				state.Err = err
+				break
+			}
+			state.Positions = append(state.Positions, Position{Holder: holder, Shares: shares})
+			if shares.Sign() > 0 {
+				conversions = append(conversions,
+					multicall.NewCall(vaultAbi, vault, "convertToAssets", shares),
+					multicall.NewCall(vaultAbi, vault, "previewRedeem", shares),
+				)
+			}
+		}
+	}
+
+	if len(conversions) == 0 {
+		return snapshot, nil
+	}
+
+	responses = caller.ExecuteAtBlock(conversions, blockNumber)
+
+	for i := range snapshot.Vaults {
+		state := &snapshot.Vaults[i]
+		if state.Err != nil {
+			continue
+		}
+
+		if perShare, err := unpackBig(responses, state.Address, "convertToAssets", oneShare(state.Decimals)); err == nil {
+			state.AssetsPerShare = perShare
+		} else {
+			state.Err = err
+		}
+
+		for j := range state.Positions {
+			position := &state.Positions[j]
+			if position.Shares.Sign() == 0 {
+				position.Assets = new(big.Int)
+				position.RedeemableAssets = new(big.Int)
+				continue
+			}
+
+			var err error
+			if position.Assets, err = unpackBig(responses, state.Address, "convertToAssets", position.Shares); err != nil {
+				state.Err = err
+			}
+			if position.RedeemableAssets, err = unpackBig(responses, state.Address, "previewRedeem", position.Shares); err != nil {
+				state.Err = err
+			}
+		}
+	}
+
+	return snapshot, nil
+}
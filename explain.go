@@ -0,0 +1,247 @@
+package go_eth_multicall
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+)
+
+const (
+	// DefaultGasCap is the gas cap geth applies to eth_call and eth_estimateGas by default
+	DefaultGasCap = 50000000
+	// DefaultMaxRequestBytes is the largest HTTP request body geth accepts by default
+	DefaultMaxRequestBytes = 5 * 1024 * 1024
+	// DefaultMaxBatchRequests is the largest JSON-RPC batch geth accepts by default
+	DefaultMaxBatchRequests = 1000
+)
+
+// requestOverhead is roughly what a JSON-RPC eth_call adds to the hex encoded calldata
+const requestOverhead = 128
+
+// ExplainOptions are the provider limits an explanation checks the execution against
+type ExplainOptions struct {
+	// GasCap is the most gas the provider lets one eth_call use, DefaultGasCap when zero
+	GasCap uint64
+	// MaxRequestBytes is the largest request the provider accepts, DefaultMaxRequestBytes when zero
+	MaxRequestBytes int
+	// MaxBatchRequests is the largest JSON-RPC batch the provider accepts, DefaultMaxBatchRequests when zero
+	MaxBatchRequests int
+}
+
+// Limit is a kind of provider limit an execution can run into
+type Limit int
+
+const (
+	// LimitGasCap is the gas cap of eth_call
+	LimitGasCap Limit = iota
+	// LimitRequestSize is the size of a request
+	LimitRequestSize
+	// LimitBatchSize is the number of requests in a JSON-RPC batch
+	LimitBatchSize
+	// LimitRate is the request rate or compute unit budget of the Limiter
+	LimitRate
+)
+
+func (limit Limit) String() string {
+	switch limit {
+	case LimitGasCap:
+		return "gas cap"
+	case LimitRequestSize:
+		return "request size"
+	case LimitBatchSize:
+		return "batch size"
+	case LimitRate:
+		return "rate limit"
+	}
+
+	return "unknown"
+}
+
+// LimitHit is a provider limit the execution would run into
+type LimitHit struct {
+	Limit Limit
+	// Chunk is the index of the chunk hitting the limit, -1 for the execution as a whole
+	Chunk  int
+	Detail string
+}
+
+func (hit LimitHit) String() string {
+	if hit.Chunk < 0 {
+		return fmt.Sprintf("%s: %s", hit.Limit, hit.Detail)
+	}
+
+	return fmt.Sprintf("%s: chunk %d: %s", hit.Limit, hit.Chunk, hit.Detail)
+}
+
+// ChunkPlan describes one aggregate, or batch, of an execution
+type ChunkPlan struct {
+	Calls int
+	// CalldataBytes is the size of the packed tryAggregate calldata, or of all the calldata of a batch
+	CalldataBytes int
+	// Gas is the estimated gas of the aggregate, zero for a batch or when it could not be estimated
+	Gas uint64
+	// GasError is why the gas could not be estimated
+	GasError string
+}
+
+// Explanation is what executing a set of calls would take
+type Explanation struct {
+	Calls int
+	// UniqueCalls is the number of calls left once the identical ones are merged
+	UniqueCalls   int
+	Strategy      Strategy
+	Chunks        []ChunkPlan
+	CalldataBytes int
+	Gas           uint64
+	// Requests and ComputeUnits are the eth_call requests the execution would send and their cost
+	Requests     int
+	ComputeUnits float64
+	// Wait is how long the Limiter would hold the execution back right now
+	Wait   time.Duration
+	Limits []LimitHit
+}
+
+// Fits reports whether the execution runs into no provider limit
+func (explanation Explanation) Fits() bool {
+	return len(explanation.Limits) == 0
+}
+
+// Explain works out how the calls would be executed at the latest block without executing them: the calls are
+// deduplicated and chunked as an execution would, each aggregate is packed and its gas estimated through
+// eth_estimateGas, and the plan is checked against the provider limits. Under the batch strategy there is no
+// aggregate, so the gas is not estimated.
+//
+// Only eth_estimateGas requests are sent, nothing is executed. They go through the Limiter like any request, after
+// the rate limit of the execution itself was checked.
+func (caller *EthMultiCaller) Explain(ctx context.Context, calls []Call, options ExplainOptions) (Explanation, error) {
+	var explanation Explanation
+	err := caller.route(ctx, func(ctx context.Context) (err error) {
+		explanation, err = caller.explain(ctx, calls, options)
+		return err
+	})
+
+	return explanation, err
+}
+
+func (caller *EthMultiCaller) explain(ctx context.Context, calls []Call, options ExplainOptions) (Explanation, error) {
+	if options.GasCap == 0 {
+		options.GasCap = DefaultGasCap
+	}
+	if options.MaxRequestBytes <= 0 {
+		options.MaxRequestBytes = DefaultMaxRequestBytes
+	}
+	if options.MaxBatchRequests <= 0 {
+		options.MaxBatchRequests = DefaultMaxBatchRequests
+	}
+
+	unique, _ := deduplicateCalls(calls)
+	chunks := chunkCalls(unique, caller.ChunkSize)
+	explanation := Explanation{Calls: len(calls), UniqueCalls: len(unique), Strategy: caller.Strategy}
+
+	explanation.Requests = len(chunks)
+	if caller.Strategy == StrategyBatch {
+		explanation.Requests = len(unique)
+	}
+
+	limiter := caller.limiter(ctx)
+	if limiter != nil {
+		explanation.ComputeUnits = float64(explanation.Requests) * limiter.Cost("eth_call")
+		explanation.Wait = limiter.delay("eth_call", explanation.Requests)
+		if explanation.Wait > 0 && limiter.options.FailFast {
+			explanation.Limits = append(explanation.Limits, LimitHit{Limit: LimitRate, Chunk: -1, Detail: "rejected, the limiter fails fast"})
+		} else if explanation.Wait > 0 {
+			explanation.Limits = append(explanation.Limits, LimitHit{Limit: LimitRate, Chunk: -1, Detail: fmt.Sprintf("held back for %s", explanation.Wait)})
+		}
+	} else {
+		cost, ok := DefaultComputeUnits["eth_call"]
+		if !ok {
+			cost = DefaultMethodCost
+		}
+		explanation.ComputeUnits = float64(explanation.Requests) * cost
+	}
+
+	for i, chunk := range chunks {
+		plan, requestBytes, err := caller.explainChunk(ctx, chunk)
+		if err != nil {
+			return Explanation{}, err
+		}
+
+		if requestBytes > options.MaxRequestBytes {
+			explanation.Limits = append(explanation.Limits, LimitHit{
+				Limit:  LimitRequestSize,
+				Chunk:  i,
+				Detail: fmt.Sprintf("about %d bytes, at most %d", requestBytes, options.MaxRequestBytes),
+			})
+		}
+		if caller.Strategy == StrategyBatch && plan.Calls > options.MaxBatchRequests {
+			explanation.Limits = append(explanation.Limits, LimitHit{
+				Limit:  LimitBatchSize,
+				Chunk:  i,
+				Detail: fmt.Sprintf("%d requests, at most %d", plan.Calls, options.MaxBatchRequests),
+			})
+		}
+		if plan.Gas > options.GasCap {
+			explanation.Limits = append(explanation.Limits, LimitHit{
+				Limit:  LimitGasCap,
+				Chunk:  i,
+				Detail: fmt.Sprintf("estimated %d gas, at most %d", plan.Gas, options.GasCap),
+			})
+		} else if strings.Contains(plan.GasError, "gas required exceeds") {
+			explanation.Limits = append(explanation.Limits, LimitHit{Limit: LimitGasCap, Chunk: i, Detail: plan.GasError})
+		}
+
+		explanation.Chunks = append(explanation.Chunks, plan)
+		explanation.CalldataBytes += plan.CalldataBytes
+		explanation.Gas += plan.Gas
+	}
+
+	return explanation, nil
+}
+
+// explainChunk plans one chunk and returns the approximate size of its request
+func (caller *EthMultiCaller) explainChunk(ctx context.Context, calls []Call) (ChunkPlan, int, error) {
+	plan := ChunkPlan{Calls: len(calls)}
+
+	if caller.Strategy == StrategyBatch {
+		for _, call := range calls {
+			plan.CalldataBytes += len(call.CallData)
+		}
+
+		return plan, 2*plan.CalldataBytes + len(calls)*requestOverhead, nil
+	}
+
+	callData, err := caller.packTryAggregate(calls)
+	if err != nil {
+		return ChunkPlan{}, 0, err
+	}
+	plan.CalldataBytes = len(callData)
+
+	gas, err := caller.estimateGas(ctx, ethereum.CallMsg{To: &caller.ContractAddress, Data: callData})
+	if err != nil {
+		// Transient failures fail the explanation, the others tell something about the aggregate itself
+		if ctx.Err() != nil || ClassifyError(err).Retryable() {
+			return ChunkPlan{}, 0, err
+		}
+		plan.GasError = err.Error()
+	}
+	plan.Gas = gas
+
+	return plan, 2*plan.CalldataBytes + requestOverhead, nil
+}
+
+// estimateGas is Client.EstimateGas admitted by the limiter and under the retry policy
+func (caller *EthMultiCaller) estimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
+	var gas uint64
+	err := caller.withRetry(ctx, func() (err error) {
+		if err := caller.limiter(ctx).Wait(ctx, "eth_estimateGas", 1); err != nil {
+			return err
+		}
+		gas, err = caller.client(ctx).EstimateGas(ctx, msg)
+		return err
+	})
+
+	return gas, err
+}
@@ -0,0 +1,276 @@
+package go_eth_multicall
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	MultiCall2 "github.com/truongpx396/go-eth-multicall/contracts/MultiCall"
+)
+
+const fakeMulticallAddress = "0xcA11bde05977b3631167028862bE2a173976CA11"
+
+// fakeResult is the answer of one call of an aggregate, tagged for the ABI encoder
+type fakeResult struct {
+	Success    bool
+	ReturnData []byte
+}
+
+// fakeNode is an in-process JSON-RPC node serving a single head block and the multicall contract. Calls answer
+// with their own calldata unless answer is set, getBlockNumber and getLastBlockHash answer for the head.
+type fakeNode struct {
+	abi    abi.ABI
+	head   *types.Header
+	client *rpc.Client
+	// extra are header fields unknown to go-ethereum, served along with head
+	extra map[string]interface{}
+	// hash overrides the hash served for head, which go-ethereum can not compute once there are extra fields
+	hash common.Hash
+
+	mu sync.Mutex
+	// answer overrides the answer of the calls of an aggregate, attempt counting the eth_calls so far
+	answer func(attempt int, call MultiCall2.Multicall2Call) (fakeResult, bool)
+	// fail makes the whole eth_call fail
+	fail    func(attempt int, calls []MultiCall2.Multicall2Call) error
+	methods map[string]int
+	// traced are the blocks debug_traceCall was asked to trace at
+	traced []rpc.BlockNumberOrHash
+	// proof answers eth_getProof
+	proof func(account common.Address, slots []common.Hash) accountResult
+}
+
+func newFakeNode(t *testing.T) (*fakeNode, *EthMultiCaller) {
+	node := startFakeNode(t)
+
+	caller := newCaller(node.client, fakeMulticallAddress)
+	caller.Retry = nil
+
+	return node, &caller
+}
+
+// newFakePool starts a fake node per endpoint name and returns a caller over the pool of them
+func newFakePool(t *testing.T, names ...string) ([]*fakeNode, *EthMultiCaller) {
+	nodes := make([]*fakeNode, len(names))
+	pool := &EndpointPool{}
+	for i, name := range names {
+		nodes[i] = startFakeNode(t)
+		pool.endpoints = append(pool.endpoints, &poolEndpoint{
+			Endpoint:  Endpoint{Name: name, Weight: 1},
+			client:    ethclient.NewClient(nodes[i].client),
+			rpcClient: nodes[i].client,
+		})
+	}
+
+	caller := newCaller(nodes[0].client, fakeMulticallAddress)
+	caller.Retry = nil
+	caller.Endpoints = pool
+
+	return nodes, &caller
+}
+
+func startFakeNode(t *testing.T) *fakeNode {
+	mcAbi, err := abi.JSON(strings.NewReader(MultiCall2.MultiCallABI))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	node := &fakeNode{
+		abi: mcAbi,
+		head: &types.Header{
+			ParentHash: common.HexToHash("0x01"),
+			Number:     big.NewInt(100),
+			Difficulty: new(big.Int),
+			Time:       1700000000,
+		},
+		methods: make(map[string]int),
+	}
+
+	server := rpc.NewServer()
+	if err := server.RegisterName("eth", &fakeEth{node}); err != nil {
+		t.Fatal(err)
+	}
+	if err := server.RegisterName("debug", &fakeDebug{node}); err != nil {
+		t.Fatal(err)
+	}
+	node.client = rpc.DialInProc(server)
+	t.Cleanup(func() {
+		node.client.Close()
+		server.Stop()
+	})
+
+	return node
+}
+
+// headHash is the hash the node reports for its head
+func (node *fakeNode) headHash() common.Hash {
+	if node.hash != (common.Hash{}) {
+		return node.hash
+	}
+
+	return node.head.Hash()
+}
+
+// headFields is the head as served over JSON-RPC, with the extra fields and the reported hash
+func (node *fakeNode) headFields() (map[string]interface{}, error) {
+	encoded, err := json.Marshal(node.head)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(encoded, &fields); err != nil {
+		return nil, err
+	}
+	for name, value := range node.extra {
+		fields[name] = value
+	}
+	fields["hash"] = node.headHash()
+
+	return fields, nil
+}
+
+func (node *fakeNode) count(method string) int {
+	node.mu.Lock()
+	defer node.mu.Unlock()
+
+	return node.methods[method]
+}
+
+func (node *fakeNode) record(method string) int {
+	node.mu.Lock()
+	defer node.mu.Unlock()
+
+	node.methods[method]++
+	return node.methods[method]
+}
+
+func (node *fakeNode) result(attempt int, call MultiCall2.Multicall2Call) fakeResult {
+	if node.answer != nil {
+		if result, ok := node.answer(attempt, call); ok {
+			return result
+		}
+	}
+
+	switch {
+	case bytes.Equal(call.CallData, node.abi.Methods["getBlockNumber"].ID):
+		return fakeResult{Success: true, ReturnData: common.BigToHash(node.head.Number).Bytes()}
+	case bytes.Equal(call.CallData, node.abi.Methods["getLastBlockHash"].ID):
+		return fakeResult{Success: true, ReturnData: node.head.ParentHash.Bytes()}
+	}
+
+	return fakeResult{Success: true, ReturnData: call.CallData}
+}
+
+type fakeEth struct {
+	node *fakeNode
+}
+
+func (eth *fakeEth) ChainId() *hexutil.Big {
+	eth.node.record("eth_chainId")
+	return (*hexutil.Big)(big.NewInt(1))
+}
+
+func (eth *fakeEth) GetBlockByNumber(number rpc.BlockNumber, full bool) (map[string]interface{}, error) {
+	eth.node.record("eth_getBlockByNumber")
+	if number >= 0 && number.Int64() != eth.node.head.Number.Int64() {
+		return nil, errors.New("header not found")
+	}
+
+	return eth.node.headFields()
+}
+
+func (eth *fakeEth) GetBlockByHash(hash common.Hash, full bool) (map[string]interface{}, error) {
+	eth.node.record("eth_getBlockByHash")
+	if hash != eth.node.headHash() {
+		return nil, errors.New("header not found")
+	}
+
+	return eth.node.headFields()
+}
+
+func (eth *fakeEth) Call(ctx context.Context, args map[string]interface{}, block rpc.BlockNumberOrHash) (hexutil.Bytes, error) {
+	node := eth.node
+	attempt := node.record("eth_call")
+	if hash, ok := block.Hash(); ok && hash != node.headHash() {
+		return nil, errors.New("header not found")
+	}
+
+	data, err := hexutil.Decode(args["data"].(string))
+	if err != nil {
+		return nil, err
+	}
+	if to := common.HexToAddress(args["to"].(string)); to != common.HexToAddress(fakeMulticallAddress) {
+		// A call sent on its own, like the batch strategy does, is handled as an aggregate of one
+		call := MultiCall2.Multicall2Call{Target: to, CallData: data}
+		if node.fail != nil {
+			if err := node.fail(attempt, []MultiCall2.Multicall2Call{call}); err != nil {
+				return nil, err
+			}
+		}
+		return node.result(attempt, call).ReturnData, nil
+	}
+	method, err := node.abi.MethodById(data)
+	if err != nil {
+		return nil, err
+	}
+	inputs, err := method.Inputs.Unpack(data[4:])
+	if err != nil {
+		return nil, err
+	}
+	calls := *abi.ConvertType(inputs[1], new([]MultiCall2.Multicall2Call)).(*[]MultiCall2.Multicall2Call)
+
+	if node.fail != nil {
+		if err := node.fail(attempt, calls); err != nil {
+			return nil, err
+		}
+	}
+
+	results := make([]fakeResult, len(calls))
+	for i, call := range calls {
+		results[i] = node.result(attempt, call)
+	}
+
+	switch method.Name {
+	case "tryAggregate":
+		return method.Outputs.Pack(results)
+	case "tryBlockAndAggregate":
+		return method.Outputs.Pack(node.head.Number, [32]byte{}, results)
+	}
+
+	return nil, errors.New("unsupported method " + method.Name)
+}
+
+func (eth *fakeEth) GetProof(account common.Address, slots []common.Hash, block rpc.BlockNumberOrHash) (*accountResult, error) {
+	eth.node.record("eth_getProof")
+	if hash, ok := block.Hash(); !ok || hash != eth.node.headHash() {
+		return nil, errors.New("header not found")
+	}
+
+	result := eth.node.proof(account, slots)
+	return &result, nil
+}
+
+type fakeDebug struct {
+	node *fakeNode
+}
+
+// TraceCall answers every trace with a single reverted frame
+func (debug *fakeDebug) TraceCall(args map[string]interface{}, block rpc.BlockNumberOrHash, config map[string]interface{}) (map[string]interface{}, error) {
+	debug.node.record("debug_traceCall")
+	debug.node.mu.Lock()
+	debug.node.traced = append(debug.node.traced, block)
+	debug.node.mu.Unlock()
+
+	return map[string]interface{}{"type": "CALL", "to": args["to"], "input": args["data"], "gas": "0x5208", "gasUsed": "0x5208", "error": "execution reverted"}, nil
+}
@@ -0,0 +1,101 @@
+package go_eth_multicall
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// blockHeader is a header along with the hash the node reported for it. go-ethereum only hashes the header fields
+// it knows of, so the hash it computes is wrong for the blocks of later forks, which add fields to the header.
+type blockHeader struct {
+	*types.Header
+	hash common.Hash
+}
+
+// Hash is the hash of the block as reported by the node
+func (header *blockHeader) Hash() common.Hash {
+	return header.hash
+}
+
+func (header *blockHeader) UnmarshalJSON(input []byte) error {
+	var decoded types.Header
+	if err := json.Unmarshal(input, &decoded); err != nil {
+		return err
+	}
+
+	var reported struct {
+		Hash *common.Hash `json:"hash"`
+	}
+	if err := json.Unmarshal(input, &reported); err != nil {
+		return err
+	}
+
+	header.Header = &decoded
+	header.hash = decoded.Hash()
+	if reported.Hash != nil {
+		header.hash = *reported.Hash
+	}
+
+	return nil
+}
+
+// getHeader fetches a header through eth_getBlockByNumber or eth_getBlockByHash. Without a JSON-RPC connection
+// the header comes from the client, with the hash go-ethereum computes.
+func (caller *EthMultiCaller) getHeader(ctx context.Context, method string, block interface{}, fallback func() (*types.Header, error)) (*blockHeader, error) {
+	rpcClient := caller.rpcClient(ctx)
+	if rpcClient == nil {
+		header, err := fallback()
+		if err != nil {
+			return nil, err
+		}
+		return &blockHeader{Header: header, hash: header.Hash()}, nil
+	}
+
+	var header *blockHeader
+	if err := rpcClient.CallContext(ctx, &header, method, block, false); err != nil {
+		return nil, err
+	}
+	if header == nil {
+		return nil, ethereum.NotFound
+	}
+
+	return header, nil
+}
+
+// headerByNumber is HeaderByNumber admitted by the limiter, keeping the hash reported by the node
+func (caller *EthMultiCaller) headerByNumber(ctx context.Context, number *big.Int) (*blockHeader, error) {
+	if err := caller.limiter(ctx).Wait(ctx, "eth_getBlockByNumber", 1); err != nil {
+		return nil, err
+	}
+
+	return caller.getHeader(ctx, "eth_getBlockByNumber", blockArg(number, nil), func() (*types.Header, error) {
+		return caller.client(ctx).HeaderByNumber(ctx, number)
+	})
+}
+
+// headerByHash is HeaderByHash admitted by the limiter, keeping the hash reported by the node
+func (caller *EthMultiCaller) headerByHash(ctx context.Context, hash common.Hash) (*blockHeader, error) {
+	if err := caller.limiter(ctx).Wait(ctx, "eth_getBlockByHash", 1); err != nil {
+		return nil, err
+	}
+
+	return caller.getHeader(ctx, "eth_getBlockByHash", hash, func() (*types.Header, error) {
+		return caller.client(ctx).HeaderByHash(ctx, hash)
+	})
+}
+
+// subscribeNewHead is SubscribeNewHead keeping the hash reported by the node
+func (caller *EthMultiCaller) subscribeNewHead(ctx context.Context, heads chan<- *blockHeader) (ethereum.Subscription, error) {
+	rpcClient := caller.rpcClient(ctx)
+	if rpcClient == nil {
+		return nil, errors.New("multicall: subscriptions need a JSON-RPC connection")
+	}
+
+	return rpcClient.EthSubscribe(ctx, heads, "newHeads")
+}
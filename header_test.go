@@ -0,0 +1,113 @@
+package go_eth_multicall
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// pragueHeader is the header layout since the Prague fork, which adds fields go-ethereum v1.10 does not know of
+type pragueHeader struct {
+	ParentHash       common.Hash
+	UncleHash        common.Hash
+	Coinbase         common.Address
+	Root             common.Hash
+	TxHash           common.Hash
+	ReceiptHash      common.Hash
+	Bloom            types.Bloom
+	Difficulty       *big.Int
+	Number           *big.Int
+	GasLimit         uint64
+	GasUsed          uint64
+	Time             uint64
+	Extra            []byte
+	MixDigest        common.Hash
+	Nonce            types.BlockNonce
+	BaseFee          *big.Int
+	WithdrawalsHash  common.Hash
+	BlobGasUsed      uint64
+	ExcessBlobGas    uint64
+	ParentBeaconRoot common.Hash
+	RequestsHash     common.Hash
+}
+
+// servePragueHead makes the node serve a head with the Shanghai, Cancun and Prague header fields, and returns the
+// hash of that head
+func servePragueHead(t *testing.T, node *fakeNode) common.Hash {
+	head := node.head
+	head.UncleHash = types.EmptyUncleHash
+	head.BaseFee = big.NewInt(7)
+	extended := pragueHeader{
+		ParentHash: head.ParentHash, UncleHash: head.UncleHash, Coinbase: head.Coinbase, Root: head.Root,
+		TxHash: head.TxHash, ReceiptHash: head.ReceiptHash, Bloom: head.Bloom, Difficulty: head.Difficulty,
+		Number: head.Number, GasLimit: head.GasLimit, GasUsed: head.GasUsed, Time: head.Time, Extra: head.Extra,
+		MixDigest: head.MixDigest, Nonce: head.Nonce, BaseFee: head.BaseFee,
+		WithdrawalsHash:  common.HexToHash("0x5a"),
+		BlobGasUsed:      131072,
+		ExcessBlobGas:    262144,
+		ParentBeaconRoot: common.HexToHash("0xbe"),
+		RequestsHash:     common.HexToHash("0x7e"),
+	}
+
+	encoded, err := rlp.EncodeToBytes(&extended)
+	if err != nil {
+		t.Fatal(err)
+	}
+	node.hash = crypto.Keccak256Hash(encoded)
+	node.extra = map[string]interface{}{
+		"withdrawalsRoot":       extended.WithdrawalsHash,
+		"blobGasUsed":           hexutil.Uint64(extended.BlobGasUsed),
+		"excessBlobGas":         hexutil.Uint64(extended.ExcessBlobGas),
+		"parentBeaconBlockRoot": extended.ParentBeaconRoot,
+		"requestsHash":          extended.RequestsHash,
+	}
+	if node.hash == head.Hash() {
+		t.Fatal("the extra fields do not change the hash")
+	}
+
+	return node.hash
+}
+
+func TestExecuteWithBlockPinsReportedHash(t *testing.T) {
+	node, caller := newFakeNode(t)
+	hash := servePragueHead(t, node)
+
+	set, err := caller.ExecuteWithBlock(context.Background(), consistencyCalls(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if set.BlockHash != hash || set.Assurance != AssuranceBlockHash {
+		t.Fatalf("got block %s with assurance %s, want %s", set.BlockHash.Hex(), set.Assurance, hash.Hex())
+	}
+}
+
+func TestExecuteContextCachedPinsReportedHash(t *testing.T) {
+	node, caller := newFakeNode(t)
+	servePragueHead(t, node)
+	caller.EnableCache()
+
+	// The fake node fails calls pinned to any other hash
+	if _, err := caller.ExecuteContext(context.Background(), consistencyCalls(), nil); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestExecuteAsOfPinsReportedHash(t *testing.T) {
+	node, caller := newFakeNode(t)
+	hash := servePragueHead(t, node)
+
+	set, err := caller.ExecuteAsOf(context.Background(), consistencyCalls(), time.Unix(int64(node.head.Time), 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if set.BlockHash != hash {
+		t.Fatalf("got block %s, want %s", set.BlockHash.Hex(), hash.Hex())
+	}
+}
@@ -0,0 +1,75 @@
+package go_eth_multicall
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// MustParseABI parses a contract ABI definition and panics when it is invalid, for package level ABI variables
+func MustParseABI(definition string) abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(definition))
+	if err != nil {
+		panic(fmt.Errorf("multicall: invalid ABI: %w", err))
+	}
+
+	return parsed
+}
+
+// CallName names a call after its target, method and arguments, e.g. 0x...Pool.ticks.100
+func CallName(target common.Address, method string, args ...interface{}) string {
+	name := target.Hex() + "." + method
+	for _, arg := range args {
+		name += fmt.Sprintf(".%v", arg)
+	}
+
+	return name
+}
+
+// NewCall packs a call of a method of contractAbi on target, named with CallName. It panics when the arguments do
+// not match the method.
+func NewCall(contractAbi abi.ABI, target common.Address, method string, args ...interface{}) Call {
+	callData, err := contractAbi.Pack(method, args...)
+	if err != nil {
+		panic(err)
+	}
+
+	return Call{Name: CallName(target, method, args...), Target: target, CallData: callData}
+}
+
+// Unpack decodes the response of a call made with NewCall. It fails when the call is missing, was unsuccessful or
+// returned nothing.
+func Unpack(contractAbi abi.ABI, responses map[string]CallResponse, target common.Address, method string, args ...interface{}) ([]interface{}, error) {
+	name := CallName(target, method, args...)
+	response, ok := responses[name]
+	if !ok || !response.Success {
+		return nil, fmt.Errorf("multicall: %s failed", name)
+	}
+
+	values, err := contractAbi.Unpack(method, response.ReturnData)
+	if err != nil {
+		return nil, fmt.Errorf("multicall: %s: %w", name, err)
+	}
+	if len(values) == 0 {
+		return nil, fmt.Errorf("multicall: %s returned no data", name)
+	}
+
+	return values, nil
+}
+
+// UnpackBlockNumber decodes the response of GetBlockNumberCall, or GetCurrentBlockTimestampCall, named name. It
+// fails unless the call succeeded with a single word, so a failed call can not pass for block 0.
+func UnpackBlockNumber(responses map[string]CallResponse, name string) (*big.Int, error) {
+	response, ok := responses[name]
+	if !ok || !response.Success {
+		return nil, fmt.Errorf("multicall: %s failed", name)
+	}
+	if len(response.ReturnData) != common.HashLength {
+		return nil, fmt.Errorf("multicall: %s returned %d bytes, not a word", name, len(response.ReturnData))
+	}
+
+	return new(big.Int).SetBytes(response.ReturnData), nil
+}
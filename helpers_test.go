@@ -0,0 +1,83 @@
+package go_eth_multicall
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestUnpackBlockNumber(t *testing.T) {
+	word := common.BigToHash(big.NewInt(15000000)).Bytes()
+
+	tests := []struct {
+		name     string
+		response *CallResponse
+		want     int64
+		fails    bool
+	}{
+		{name: "success", response: &CallResponse{Success: true, ReturnData: word}, want: 15000000},
+		{name: "missing", fails: true},
+		{name: "failed", response: &CallResponse{Success: false, ReturnData: word}, fails: true},
+		{name: "empty", response: &CallResponse{Success: true}, fails: true},
+		{name: "short", response: &CallResponse{Success: true, ReturnData: word[1:]}, fails: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			responses := make(map[string]CallResponse)
+			if test.response != nil {
+				responses["blockNumber"] = *test.response
+			}
+
+			blockNumber, err := UnpackBlockNumber(responses, "blockNumber")
+			if test.fails {
+				if err == nil {
+					t.Fatalf("got block %v, want an error", blockNumber)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if blockNumber.Int64() != test.want {
+				t.Fatalf("got block %v, want %d", blockNumber, test.want)
+			}
+		})
+	}
+}
+
+func TestNewCallAndUnpack(t *testing.T) {
+	mcAbi := MustParseABI(`[{"inputs":[{"name":"addr","type":"address"}],"name":"getEthBalance","outputs":[{"name":"balance","type":"uint256"}],"stateMutability":"view","type":"function"}]`)
+	target := common.HexToAddress("0x5BA1e12693Dc8F9c48aAD8770482f4739bEeD696")
+	holder := common.HexToAddress("0xb1adceddb2941033a090dd166a462fe1c2029484")
+
+	call := NewCall(mcAbi, target, "getEthBalance", holder)
+	if want := target.Hex() + ".getEthBalance." + holder.Hex(); call.Name != want {
+		t.Fatalf("got name %s, want %s", call.Name, want)
+	}
+
+	responses := map[string]CallResponse{call.Name: {Success: true, ReturnData: common.BigToHash(big.NewInt(42)).Bytes()}}
+	values, err := Unpack(mcAbi, responses, target, "getEthBalance", holder)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if values[0].(*big.Int).Int64() != 42 {
+		t.Fatalf("got %v, want 42", values[0])
+	}
+
+	responses[call.Name] = CallResponse{Success: false}
+	if _, err := Unpack(mcAbi, responses, target, "getEthBalance", holder); err == nil {
+		t.Fatal("unpacked a failed call")
+	}
+}
+
+func TestMustParseABIPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("did not panic on an invalid ABI")
+		}
+	}()
+
+	MustParseABI("not an abi")
+}
@@ -0,0 +1,275 @@
+package go_eth_multicall
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrRateLimited is returned by a fail-fast Limiter when a request would exceed its limits
+var ErrRateLimited = errors.New("multicall: request would exceed the rate limit")
+
+// DefaultMethodCost is the compute unit cost of the methods missing from the cost model
+const DefaultMethodCost = 10
+
+// DefaultComputeUnits is a compute unit cost model per RPC method, in line with the pricing of the common providers
+var DefaultComputeUnits = map[string]float64{
+	"eth_call":             26,
+	"eth_chainId":          0,
+	"eth_blockNumber":      10,
+	"eth_getBlockByNumber": 16,
+	"eth_getBlockByHash":   16,
+	"eth_estimateGas":      87,
+	"eth_getProof":         21,
+	"eth_subscribe":        10,
+	"debug_traceCall":      309,
+}
+
+// LimiterOptions are the limits of a Limiter. A zero rate means no limit.
+type LimiterOptions struct {
+	// RequestsPerSecond is the sustained request rate, each call of a JSON-RPC batch counting as a request
+	RequestsPerSecond float64
+	// RequestBurst is the number of requests that can be sent at once after a quiet period, one second worth of
+	// requests when zero
+	RequestBurst float64
+	// ComputeUnitsPerSecond is the sustained compute unit rate
+	ComputeUnitsPerSecond float64
+	// ComputeUnitBurst is the number of compute units that can be spent at once after a quiet period, one second
+	// worth of compute units when zero
+	ComputeUnitBurst float64
+	// Costs is the compute unit cost per method, DefaultComputeUnits when nil
+	Costs map[string]float64
+	// FailFast rejects the requests over the limits with ErrRateLimited instead of making them wait. A request
+	// larger than a burst is admitted once the bucket is full, taking the rest in advance.
+	FailFast bool
+}
+
+// MethodUsage is what the requests of one method consumed
+type MethodUsage struct {
+	Requests     uint64
+	ComputeUnits float64
+}
+
+// BudgetReport is the consumption of a Limiter since it was created
+type BudgetReport struct {
+	Since        time.Time
+	Requests     uint64
+	ComputeUnits float64
+	ByMethod     map[string]MethodUsage
+	// Rejected is the number of requests refused in fail-fast mode
+	Rejected uint64
+	// Waited is the total time requests were held back in blocking mode
+	Waited time.Duration
+	// AvailableRequests and AvailableComputeUnits are what can be spent right now without waiting
+	AvailableRequests     float64
+	AvailableComputeUnits float64
+}
+
+// tokenBucket is filled at rate tokens per second up to capacity. Blocking admissions take their tokens in advance,
+// leaving the bucket negative until it refills.
+type tokenBucket struct {
+	rate     float64
+	capacity float64
+	tokens   float64
+	last     time.Time
+}
+
+func newTokenBucket(rate, capacity float64, now time.Time) tokenBucket {
+	if capacity <= 0 {
+		capacity = rate
+	}
+
+	return tokenBucket{rate: rate, capacity: capacity, tokens: capacity, last: now}
+}
+
+func (bucket *tokenBucket) refill(now time.Time) {
+	if bucket.rate <= 0 {
+		return
+	}
+
+	bucket.tokens += now.Sub(bucket.last).Seconds() * bucket.rate
+	if bucket.tokens > bucket.capacity {
+		bucket.tokens = bucket.capacity
+	}
+	bucket.last = now
+}
+
+// delay returns how long to wait until the bucket holds the tokens
+func (bucket *tokenBucket) delay(tokens float64) time.Duration {
+	if bucket.rate <= 0 || bucket.tokens >= tokens {
+		return 0
+	}
+
+	return time.Duration((tokens - bucket.tokens) / bucket.rate * float64(time.Second))
+}
+
+// clamp caps tokens at the capacity of the bucket, the most it can ever hold
+func (bucket *tokenBucket) clamp(tokens float64) float64 {
+	if bucket.rate > 0 && tokens > bucket.capacity {
+		return bucket.capacity
+	}
+
+	return tokens
+}
+
+func (bucket *tokenBucket) take(tokens float64) {
+	if bucket.rate > 0 {
+		bucket.tokens -= tokens
+	}
+}
+
+// Limiter admits the requests sent to one endpoint under a request rate and a compute unit budget, both enforced
+// by token buckets. Set it as the Limiter of an EthMultiCaller, or of an Endpoint of a pool, so that bulk scans can
+// share a provider with latency sensitive traffic without tripping its limits.
+//
+// A Limiter is safe for concurrent use and can be shared by several callers of the same provider.
+type Limiter struct {
+	options LimiterOptions
+
+	mu       sync.Mutex
+	requests tokenBucket
+	units    tokenBucket
+	report   BudgetReport
+}
+
+func NewLimiter(options LimiterOptions) *Limiter {
+	if options.Costs == nil {
+		options.Costs = DefaultComputeUnits
+	}
+
+	now := time.Now()
+	return &Limiter{
+		options:  options,
+		requests: newTokenBucket(options.RequestsPerSecond, options.RequestBurst, now),
+		units:    newTokenBucket(options.ComputeUnitsPerSecond, options.ComputeUnitBurst, now),
+		report:   BudgetReport{Since: now, ByMethod: make(map[string]MethodUsage)},
+	}
+}
+
+// Cost returns the compute units of one request of the method
+func (limiter *Limiter) Cost(method string) float64 {
+	if cost, ok := limiter.options.Costs[method]; ok {
+		return cost
+	}
+
+	return DefaultMethodCost
+}
+
+// Wait admits count requests of the method, waiting for the buckets to refill unless the limiter fails fast.
+// It is a no-op on a nil Limiter.
+func (limiter *Limiter) Wait(ctx context.Context, method string, count int) error {
+	if limiter == nil {
+		return nil
+	}
+
+	requests := float64(count)
+	units := requests * limiter.Cost(method)
+
+	limiter.mu.Lock()
+	now := time.Now()
+	limiter.requests.refill(now)
+	limiter.units.refill(now)
+
+	waitRequests, waitUnits := requests, units
+	if limiter.options.FailFast {
+		// A request larger than a bucket would be rejected forever
+		waitRequests, waitUnits = limiter.requests.clamp(requests), limiter.units.clamp(units)
+	}
+	delay := limiter.requests.delay(waitRequests)
+	if unitsDelay := limiter.units.delay(waitUnits); unitsDelay > delay {
+		delay = unitsDelay
+	}
+	if delay > 0 && limiter.options.FailFast {
+		limiter.report.Rejected += uint64(count)
+		limiter.mu.Unlock()
+		return ErrRateLimited
+	}
+	if deadline, ok := ctx.Deadline(); ok && now.Add(delay).After(deadline) {
+		limiter.mu.Unlock()
+		return context.DeadlineExceeded
+	}
+
+	limiter.requests.take(requests)
+	limiter.units.take(units)
+	limiter.report.Requests += uint64(count)
+	limiter.report.ComputeUnits += units
+	limiter.report.Waited += delay
+	usage := limiter.report.ByMethod[method]
+	usage.Requests += uint64(count)
+	usage.ComputeUnits += units
+	limiter.report.ByMethod[method] = usage
+	limiter.mu.Unlock()
+
+	if delay == 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		// Give back what was taken in advance
+		limiter.mu.Lock()
+		limiter.requests.take(-requests)
+		limiter.units.take(-units)
+		limiter.report.Requests -= uint64(count)
+		limiter.report.ComputeUnits -= units
+		usage := limiter.report.ByMethod[method]
+		usage.Requests -= uint64(count)
+		usage.ComputeUnits -= units
+		limiter.report.ByMethod[method] = usage
+		limiter.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// delay returns how long count requests of the method would wait right now, without admitting them
+func (limiter *Limiter) delay(method string, count int) time.Duration {
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+
+	now := time.Now()
+	limiter.requests.refill(now)
+	limiter.units.refill(now)
+
+	delay := limiter.requests.delay(float64(count))
+	if unitsDelay := limiter.units.delay(float64(count) * limiter.Cost(method)); unitsDelay > delay {
+		delay = unitsDelay
+	}
+
+	return delay
+}
+
+// Report returns what was consumed since the limiter was created and what is available right now
+func (limiter *Limiter) Report() BudgetReport {
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+
+	now := time.Now()
+	limiter.requests.refill(now)
+	limiter.units.refill(now)
+
+	report := limiter.report
+	report.ByMethod = make(map[string]MethodUsage, len(limiter.report.ByMethod))
+	for method, usage := range limiter.report.ByMethod {
+		report.ByMethod[method] = usage
+	}
+	report.AvailableRequests = limiter.requests.tokens
+	report.AvailableComputeUnits = limiter.units.tokens
+
+	return report
+}
+
+// limiter returns the limiter of the endpoint the execution was routed to, or Limiter when it was not routed or
+// the endpoint has no limiter of its own
+func (caller *EthMultiCaller) limiter(ctx context.Context) *Limiter {
+	if endpoint := endpointFrom(ctx); endpoint != nil && endpoint.Limiter != nil {
+		return endpoint.Limiter
+	}
+
+	return caller.Limiter
+}
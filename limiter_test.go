@@ -0,0 +1,112 @@
+package go_eth_multicall
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTokenBucket(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+
+	tests := []struct {
+		name     string
+		rate     float64
+		capacity float64
+		take     float64
+		elapsed  time.Duration
+		want     float64
+		delay    float64
+		wantWait time.Duration
+	}{
+		{name: "starts full", rate: 10, capacity: 20, want: 20},
+		{name: "capacity defaults to rate", rate: 10, want: 10},
+		{name: "refills at rate", rate: 10, capacity: 20, take: 20, elapsed: 500 * time.Millisecond, want: 5},
+		{name: "refill is capped", rate: 10, capacity: 20, take: 5, elapsed: time.Minute, want: 20},
+		{name: "goes negative in advance", rate: 10, capacity: 10, take: 15, want: -5, delay: 5, wantWait: time.Second},
+		{name: "waits for missing tokens", rate: 4, capacity: 4, take: 4, delay: 2, wantWait: 500 * time.Millisecond},
+		{name: "no limit", rate: 0, capacity: 0, take: 100, want: 0, delay: 1000},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bucket := newTokenBucket(test.rate, test.capacity, start)
+			bucket.take(test.take)
+			bucket.refill(start.Add(test.elapsed))
+
+			if bucket.tokens != test.want {
+				t.Errorf("got %v tokens, want %v", bucket.tokens, test.want)
+			}
+			if wait := bucket.delay(test.delay); wait != test.wantWait {
+				t.Errorf("got a delay of %v for %v tokens, want %v", wait, test.delay, test.wantWait)
+			}
+		})
+	}
+}
+
+func TestLimiterFailFast(t *testing.T) {
+	limiter := NewLimiter(LimiterOptions{RequestsPerSecond: 1, ComputeUnitsPerSecond: 100, FailFast: true})
+	ctx := context.Background()
+
+	// 3 eth_calls are 78 compute units, within the budget but over the request burst, which is full
+	if err := limiter.Wait(ctx, "eth_call", 3); err != nil {
+		t.Fatalf("got %v for a request larger than the burst", err)
+	}
+	if err := limiter.Wait(ctx, "eth_call", 1); !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("got %v, want ErrRateLimited once the requests are spent in advance", err)
+	}
+	if err := limiter.Wait(ctx, "eth_chainId", 1); !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("got %v, want ErrRateLimited once the requests are spent", err)
+	}
+
+	report := limiter.Report()
+	if report.Requests != 3 || report.ComputeUnits != 78 || report.Rejected != 2 {
+		t.Fatalf("got %d requests, %v compute units and %d rejected", report.Requests, report.ComputeUnits, report.Rejected)
+	}
+	if usage := report.ByMethod["eth_call"]; usage.Requests != 3 || usage.ComputeUnits != 78 {
+		t.Fatalf("got %+v for eth_call", usage)
+	}
+}
+
+func TestLimiterGivesBackOnCancel(t *testing.T) {
+	limiter := NewLimiter(LimiterOptions{RequestsPerSecond: 1})
+	if err := limiter.Wait(context.Background(), "eth_call", 1); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := limiter.Wait(ctx, "eth_call", 1); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got %v, want the deadline to be exceeded", err)
+	}
+	if report := limiter.Report(); report.Requests != 1 {
+		t.Fatalf("got %d requests, want the waiting one given back", report.Requests)
+	}
+}
+
+func TestCallerLimiterFallback(t *testing.T) {
+	own := NewLimiter(LimiterOptions{})
+	shared := NewLimiter(LimiterOptions{})
+	caller := &EthMultiCaller{Limiter: shared}
+
+	tests := []struct {
+		name     string
+		endpoint *poolEndpoint
+		want     *Limiter
+	}{
+		{name: "not routed", want: shared},
+		{name: "endpoint without limiter", endpoint: &poolEndpoint{}, want: shared},
+		{name: "endpoint with limiter", endpoint: &poolEndpoint{Endpoint: Endpoint{Limiter: own}}, want: own},
+	}
+
+	for _, test := range tests {
+		ctx := context.Background()
+		if test.endpoint != nil {
+			ctx = context.WithValue(ctx, endpointKey{}, test.endpoint)
+		}
+		if got := caller.limiter(ctx); got != test.want {
+			t.Errorf("%s: got the wrong limiter", test.name)
+		}
+	}
+}
@@ -3,8 +3,10 @@ package go_eth_multicall
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"math/big"
 	"strings"
+	"sync/atomic"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi"
@@ -12,6 +14,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
 	MultiCall2 "github.com/truongpx396/go-eth-multicall/contracts/MultiCall"
 )
 
@@ -19,11 +22,19 @@ type Call struct {
 	Name     string         `json:"name"`
 	Target   common.Address `json:"target"`
 	CallData []byte         `json:"call_data"`
+	// Outputs optionally describes the return data, e.g. contractAbi.Methods["balanceOf"].Outputs.
+	// It is not needed to execute the call, but lets DiffResults report numeric deltas.
+	Outputs abi.Arguments `json:"-"`
 }
 
 type CallResponse struct {
 	Success    bool   `json:"success"`
 	ReturnData []byte `json:"returnData"`
+	// Error is set on the calls that could not run within an aggregate, e.g. because they ran out of gas or returned
+	// too much data. Calls that merely reverted leave it empty.
+	Error string `json:"error,omitempty"`
+	// Trace is the call tree of an unsuccessful call, when TraceFailures is set and the node could trace it
+	Trace *CallTrace `json:"trace,omitempty"`
 }
 
 func (call Call) GetMultiCall() MultiCall2.Multicall2Call {
@@ -57,14 +68,49 @@ type EthMultiCaller struct {
 	Client          *ethclient.Client
 	Abi             abi.ABI
 	ContractAddress common.Address
+	// RPCClient is the connection underlying Client, used for JSON-RPC batches. It may be nil.
+	RPCClient *rpc.Client
+	// Cache is an optional per-block cache of call responses, see EnableCache
+	Cache *CallCache
+	// Store is an optional persistent cache of the responses read at finalized blocks, see OpenFileStore
+	Store ResultStore
+	// Retry is the policy calls to the node are retried with, nil disables retries
+	Retry *RetryPolicy
+	// Strategy is how calls are sent to the node, through the multicall contract by default
+	Strategy Strategy
+	// ChunkSize splits the calls into aggregates, or batches, of at most ChunkSize calls each, no limit when zero
+	ChunkSize int
+	// Consistent pins every chunk of an execution to one block hash and checks the block each chunk reports, see
+	// Assurance
+	Consistent bool
+	// TraceFailures re-runs the unsuccessful calls through debug_traceCall and attaches their call tree
+	TraceFailures bool
+	// Limiter optionally keeps the requests under the rate limits of the provider, see NewLimiter
+	Limiter *Limiter
+	// Chain is the chain the caller is connected to, set by NewForChain when the chain is known
+	Chain *Chain
+	// Variant is the kind of multicall contract at ContractAddress, set by NewForChain. Executions at blocks before
+	// its deployment run through the newest multicall contract of Chain deployed by then.
+	Variant string
+	// Endpoints spreads executions over several endpoints when set, see NewWithEndpoints
+	Endpoints *EndpointPool
+
+	chainID   atomic.Value
+	finalized atomic.Value
 }
 
 func New(rawurl, multilcalContractAddress string) EthMultiCaller {
-	client, err := ethclient.Dial(rawurl)
+	rpcClient, err := rpc.Dial(rawurl)
 	if err != nil {
 		panic(err)
 	}
 
+	return newCaller(rpcClient, multilcalContractAddress)
+}
+
+func newCaller(rpcClient *rpc.Client, multilcalContractAddress string) EthMultiCaller {
+	client := ethclient.NewClient(rpcClient)
+
 	// Load Multicall abi for later use
 	mcAbi, err := abi.JSON(strings.NewReader(MultiCall2.MultiCallABI))
 	if err != nil {
@@ -76,14 +122,244 @@ func New(rawurl, multilcalContractAddress string) EthMultiCaller {
 	return EthMultiCaller{
 		Signer:          randomSigner(),
 		Client:          client,
+		RPCClient:       rpcClient,
 		Abi:             mcAbi,
 		ContractAddress: contractAddress,
+		Retry:           DefaultRetryPolicy(),
+	}
+}
+
+// GetBlockNumberCall returns a Call that reads the number of the block the aggregate is executed in
+func (caller *EthMultiCaller) GetBlockNumberCall(name string) Call {
+	callData, err := caller.Abi.Pack("getBlockNumber")
+	if err != nil {
+		panic(err)
 	}
+
+	return Call{Name: name, Target: caller.ContractAddress, CallData: callData}
+}
+
+// GetLastBlockHashCall returns a Call that reads the hash of the parent of the block the aggregate is executed in
+func (caller *EthMultiCaller) GetLastBlockHashCall(name string) Call {
+	callData, err := caller.Abi.Pack("getLastBlockHash")
+	if err != nil {
+		panic(err)
+	}
+
+	return Call{Name: name, Target: caller.ContractAddress, CallData: callData}
+}
+
+// GetCurrentBlockTimestampCall returns a Call that reads the timestamp of the block the aggregate is executed in
+func (caller *EthMultiCaller) GetCurrentBlockTimestampCall(name string) Call {
+	callData, err := caller.Abi.Pack("getCurrentBlockTimestamp")
+	if err != nil {
+		panic(err)
+	}
+
+	return Call{Name: name, Target: caller.ContractAddress, CallData: callData}
 }
 
 func (caller *EthMultiCaller) Execute(calls []Call) map[string]CallResponse {
+	return caller.ExecuteAtBlock(calls, nil)
+}
+
+// ExecuteAtBlock performs the multicall against the state of the given block, nil meaning the latest block
+func (caller *EthMultiCaller) ExecuteAtBlock(calls []Call, blockNumber *big.Int) map[string]CallResponse {
+	responses, err := caller.ExecuteContext(context.Background(), calls, blockNumber)
+	if err != nil {
+		panic(err)
+	}
+
+	// Create mapping for results. Be aware that we sometimes get two empty results initially, unsure why
+	results := make(map[string]CallResponse)
+	for i, call := range calls {
+		results[call.Name] = responses[i]
+	}
+
+	return results
+}
+
+// ExecuteContext performs the multicall against the state of the given block, nil meaning the latest block.
+// Unlike Execute it returns an error instead of panicking, gives up when ctx is done and returns the responses
+// in the order of the calls.
+func (caller *EthMultiCaller) ExecuteContext(ctx context.Context, calls []Call, blockNumber *big.Int) ([]CallResponse, error) {
 	var responses []CallResponse
+	err := caller.route(ctx, func(ctx context.Context) (err error) {
+		responses, err = caller.executeContext(ctx, calls, blockNumber)
+		return err
+	})
+
+	return responses, err
+}
+
+func (caller *EthMultiCaller) executeContext(ctx context.Context, calls []Call, blockNumber *big.Int) ([]CallResponse, error) {
+	return executeDeduplicated(calls, func(unique []Call) ([]CallResponse, error) {
+		// The latest block is never finalized, so only the cache applies to it
+		stored := caller.Store != nil && blockNumber != nil
+		if caller.Cache == nil && !stored {
+			return caller.tryAggregate(ctx, unique, blockNumber)
+		}
+
+		header, err := caller.headerByNumber(ctx, blockNumber)
+		if err != nil {
+			return nil, err
+		}
+
+		if stored {
+			if responses, ok, err := caller.executeStored(ctx, unique, header); ok || err != nil {
+				return responses, err
+			}
+		}
+		if caller.Cache == nil {
+			return caller.tryAggregateAtHash(ctx, unique, header.Hash())
+		}
+
+		return caller.executeCached(ctx, unique, header.Hash(), blockNumber == nil)
+	})
+}
+
+// ExecuteAtHash is like ExecuteContext but pins the multicall to the block with the given hash, so the responses
+// can not come from another block at the same height
+func (caller *EthMultiCaller) ExecuteAtHash(ctx context.Context, calls []Call, blockHash common.Hash) ([]CallResponse, error) {
+	var responses []CallResponse
+	err := caller.route(ctx, func(ctx context.Context) (err error) {
+		responses, err = caller.executeAtHash(ctx, calls, blockHash)
+		return err
+	})
+
+	return responses, err
+}
+
+func (caller *EthMultiCaller) executeAtHash(ctx context.Context, calls []Call, blockHash common.Hash) ([]CallResponse, error) {
+	return executeDeduplicated(calls, func(unique []Call) ([]CallResponse, error) {
+		if caller.Store != nil {
+			header, err := caller.headerByHash(ctx, blockHash)
+			if err != nil {
+				return nil, err
+			}
+			if responses, ok, err := caller.executeStored(ctx, unique, header); ok || err != nil {
+				return responses, err
+			}
+		}
+		if caller.Cache == nil {
+			return caller.tryAggregateAtHash(ctx, unique, blockHash)
+		}
+
+		return caller.executeCached(ctx, unique, blockHash, false)
+	})
+}
+
+// ExecuteWithBlock performs the multicall through tryBlockAndAggregate at the given block, nil meaning the latest
+// block, and returns the responses along with the block the aggregate actually ran in.
+//
+// The header of the block is resolved first and every aggregate is pinned to its hash, so the hash, parent hash and
+// timestamp of the result set always describe the block the responses were read from.
+func (caller *EthMultiCaller) ExecuteWithBlock(ctx context.Context, calls []Call, blockNumber *big.Int) (ResultSet, error) {
+	ctx, retries := countRetries(ctx)
+
+	var set ResultSet
+	err := caller.route(ctx, func(ctx context.Context) (err error) {
+		set, err = caller.executeWithBlock(ctx, calls, blockNumber)
+		return err
+	})
+	if err != nil {
+		return ResultSet{}, err
+	}
+	set.Retries = int(atomic.LoadInt64(retries))
+
+	return set, nil
+}
+
+func (caller *EthMultiCaller) executeWithBlock(ctx context.Context, calls []Call, blockNumber *big.Int) (ResultSet, error) {
+	ctx, err := caller.withMulticallAt(ctx, blockNumber)
+	if err != nil {
+		return ResultSet{}, err
+	}
+
+	if caller.Strategy == StrategyBatch {
+		// Without tryBlockAndAggregate, resolve the block first and pin the batch to it
+		header, err := caller.headerByNumber(ctx, blockNumber)
+		if err != nil {
+			return ResultSet{}, err
+		}
+
+		set := newResultSet(header, calls)
+		set.Assurance = AssuranceBlockHash
+		set.Responses, err = executeDeduplicated(calls, func(unique []Call) ([]CallResponse, error) {
+			return caller.tryAggregateAtHash(ctx, unique, set.BlockHash)
+		})
+		if err != nil {
+			return ResultSet{}, err
+		}
+
+		return set, nil
+	}
+
+	// Resolve the block first and pin every chunk to its hash, so the result set is labelled with the block its
+	// responses come from even when the chain reorganizes meanwhile
+	header, err := caller.headerByNumber(ctx, blockNumber)
+	if err != nil {
+		return ResultSet{}, err
+	}
+
+	set := newResultSet(header, calls)
+	set.Responses, err = executeDeduplicated(calls, func(unique []Call) ([]CallResponse, error) {
+		if caller.Consistent && len(chunkCalls(unique, caller.ChunkSize)) > 1 {
+			return caller.blockAndAggregateConsistent(ctx, unique, blockNumber, &set)
+		}
+
+		return caller.blockAndAggregateChunks(ctx, unique, header, &set)
+	})
+	if err != nil {
+		return ResultSet{}, err
+	}
+
+	return set, nil
+}
+
+// executeDeduplicated runs execute on the distinct calls and maps the responses back to every call
+func executeDeduplicated(calls []Call, execute func(unique []Call) ([]CallResponse, error)) ([]CallResponse, error) {
+	unique, indexes := deduplicateCalls(calls)
+
+	uniqueResponses, err := execute(unique)
+	if err != nil {
+		return nil, err
+	}
+	if len(uniqueResponses) != len(unique) {
+		return nil, fmt.Errorf("multicall: got %d responses for %d calls", len(uniqueResponses), len(unique))
+	}
+
+	responses := make([]CallResponse, len(calls))
+	for i := range calls {
+		responses[i] = uniqueResponses[indexes[i]]
+	}
+
+	return responses, nil
+}
+
+// deduplicateCalls drops the calls with the same target and calldata as an earlier call. It returns the remaining
+// calls along with the index of the remaining call standing in for each of the original calls.
+func deduplicateCalls(calls []Call) ([]Call, []int) {
+	unique := make([]Call, 0, len(calls))
+	indexes := make([]int, len(calls))
+	seen := make(map[string]int, len(calls))
+
+	for i, call := range calls {
+		key := string(call.Target.Bytes()) + string(call.CallData)
+		if index, ok := seen[key]; ok {
+			indexes[i] = index
+			continue
+		}
+
+		seen[key] = len(unique)
+		indexes[i] = len(unique)
+		unique = append(unique, call)
+	}
+
+	return unique, indexes
+}
 
+func (caller *EthMultiCaller) packTryAggregate(calls []Call) ([]byte, error) {
 	var multiCalls = make([]MultiCall2.Multicall2Call, 0, len(calls))
 
 	// Add calls to multicall structure for the contract
@@ -92,41 +368,202 @@ func (caller *EthMultiCaller) Execute(calls []Call) map[string]CallResponse {
 	}
 
 	// Prepare calldata for multicall
-	callData, err := caller.Abi.Pack("tryAggregate", false, multiCalls)
+	return caller.Abi.Pack("tryAggregate", false, multiCalls)
+}
+
+func (caller *EthMultiCaller) unpackTryAggregate(resp []byte) ([]CallResponse, error) {
+	var responses []CallResponse
+
+	// Unpack results
+	unpackedResp, err := caller.Abi.Unpack("tryAggregate", resp)
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 
-	// Perform multicall
-	resp, err := caller.Client.CallContract(context.Background(), ethereum.CallMsg{To: &caller.ContractAddress, Data: callData}, nil)
+	a, err := json.Marshal(unpackedResp[0])
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 
-	// Unpack results
-	unpackedResp, _ := caller.Abi.Unpack("tryAggregate", resp)
+	err = json.Unmarshal(a, &responses)
+	if err != nil {
+		return nil, err
+	}
 
-	a, err := json.Marshal(unpackedResp[0])
+	return responses, nil
+}
+
+// tryAggregate sends the calls in a single tryAggregate executed at the given block, nil meaning the latest block.
+// When a call poisons the whole aggregate, the calls are bisected, see bisectAggregate.
+func (caller *EthMultiCaller) tryAggregate(ctx context.Context, calls []Call, blockNumber *big.Int) ([]CallResponse, error) {
+	ctx, err := caller.withMulticallAt(ctx, blockNumber)
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 
-	err = json.Unmarshal(a, &responses)
+	consistent := caller.Consistent && len(chunkCalls(calls, caller.ChunkSize)) > 1
+	if consistent || (caller.TraceFailures && blockNumber == nil) {
+		// Pin every chunk to the same block, which is also the block the failures are traced at
+		header, err := caller.headerByNumber(ctx, blockNumber)
+		if err != nil {
+			return nil, err
+		}
+
+		return caller.tryAggregateAtHash(ctx, calls, header.Hash())
+	}
+
+	responses, err := executeChunks(calls, caller.ChunkSize, func(calls []Call) ([]CallResponse, error) {
+		if caller.Strategy == StrategyBatch {
+			return caller.batchCall(ctx, calls, blockNumber, nil)
+		}
+
+		return caller.tryAggregateChunk(ctx, calls, blockNumber)
+	})
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
+	caller.traceFailures(ctx, calls, responses, blockArg(blockNumber, nil))
 
-	// Create mapping for results. Be aware that we sometimes get two empty results initially, unsure why
-	results := make(map[string]CallResponse)
-	for i, response := range responses {
-		results[calls[i].Name] = response
+	return responses, nil
+}
+
+func (caller *EthMultiCaller) tryAggregateChunk(ctx context.Context, calls []Call, blockNumber *big.Int) ([]CallResponse, error) {
+	responses, err := caller.aggregate(ctx, calls, blockNumber)
+	if err == nil || !poisoned(ctx, err) {
+		return responses, err
 	}
 
-	return results
+	if blockNumber == nil {
+		// Pin the bisection to one block, the parts would otherwise run at whatever the latest block is by then
+		header, headerErr := caller.headerByNumber(ctx, nil)
+		if headerErr != nil {
+			return nil, err
+		}
+		blockHash := header.Hash()
+
+		return caller.bisectAggregate(ctx, calls, err, func(calls []Call) ([]CallResponse, error) {
+			return caller.aggregateAtHash(ctx, calls, blockHash)
+		})
+	}
+
+	return caller.bisectAggregate(ctx, calls, err, func(calls []Call) ([]CallResponse, error) {
+		return caller.aggregate(ctx, calls, blockNumber)
+	})
 }
 
-// This function supports to get nativeBalance while querying other balances
+// tryAggregateAtHash sends the calls in a single tryAggregate executed at the block with the given hash.
+// When a call poisons the whole aggregate, the calls are bisected, see bisectAggregate.
+func (caller *EthMultiCaller) tryAggregateAtHash(ctx context.Context, calls []Call, blockHash common.Hash) ([]CallResponse, error) {
+	aggregate := func(calls []Call) ([]CallResponse, error) {
+		return caller.aggregateAtHash(ctx, calls, blockHash)
+	}
+
+	responses, err := executeChunks(calls, caller.ChunkSize, func(calls []Call) ([]CallResponse, error) {
+		if caller.Strategy == StrategyBatch {
+			return caller.batchCall(ctx, calls, nil, &blockHash)
+		}
+
+		responses, err := aggregate(calls)
+		if err == nil || !poisoned(ctx, err) {
+			return responses, err
+		}
+
+		return caller.bisectAggregate(ctx, calls, err, aggregate)
+	})
+	if err != nil {
+		return nil, err
+	}
+	caller.traceFailures(ctx, calls, responses, blockArg(nil, &blockHash))
+
+	return responses, nil
+}
+
+// chunkCalls splits the calls into chunks of at most size calls, a single chunk when size is zero
+func chunkCalls(calls []Call, size int) [][]Call {
+	if size <= 0 || len(calls) <= size {
+		return [][]Call{calls}
+	}
+
+	chunks := make([][]Call, 0, (len(calls)+size-1)/size)
+	for start := 0; start < len(calls); start += size {
+		end := start + size
+		if end > len(calls) {
+			end = len(calls)
+		}
+		chunks = append(chunks, calls[start:end])
+	}
+
+	return chunks
+}
+
+// executeChunks runs execute on each chunk of the calls in turn and concatenates the responses
+func executeChunks(calls []Call, size int, execute func(calls []Call) ([]CallResponse, error)) ([]CallResponse, error) {
+	chunks := chunkCalls(calls, size)
+	if len(chunks) == 1 {
+		return execute(calls)
+	}
+
+	responses := make([]CallResponse, 0, len(calls))
+	for _, chunk := range chunks {
+		chunkResponses, err := execute(chunk)
+		if err != nil {
+			return nil, err
+		}
+		if len(chunkResponses) != len(chunk) {
+			return nil, fmt.Errorf("multicall: got %d responses for %d calls", len(chunkResponses), len(chunk))
+		}
+		responses = append(responses, chunkResponses...)
+	}
+
+	return responses, nil
+}
+
+func (caller *EthMultiCaller) aggregate(ctx context.Context, calls []Call, blockNumber *big.Int) ([]CallResponse, error) {
+	multicall := caller.multicall(ctx)
+	callData, err := caller.packTryAggregate(caller.retarget(calls, multicall))
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := caller.callContract(ctx, ethereum.CallMsg{To: &multicall, Data: callData}, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	return caller.unpackTryAggregate(resp)
+}
+
+func (caller *EthMultiCaller) aggregateAtHash(ctx context.Context, calls []Call, blockHash common.Hash) ([]CallResponse, error) {
+	multicall := caller.multicall(ctx)
+	callData, err := caller.packTryAggregate(caller.retarget(calls, multicall))
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := caller.callContractAtHash(ctx, ethereum.CallMsg{To: &multicall, Data: callData}, blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	return caller.unpackTryAggregate(resp)
+}
+
+// This function supports to get nativeBalance while querying other balances. It goes through the custom contract of
+// Chain when the caller has another variant.
 func (caller *EthMultiCaller) ExecuteBalances(calls []Call, userAddress string) map[string]CallResponse {
+	multicall := caller.ContractAddress
+	if caller.Variant != "" && caller.Variant != VariantCustom {
+		var deployment MulticallDeployment
+		var ok bool
+		if caller.Chain != nil {
+			deployment, ok = caller.Chain.Multicalls[VariantCustom]
+		}
+		if !ok {
+			panic(fmt.Errorf("multicall: ExecuteBalances needs the %s contract, not %s", VariantCustom, caller.Variant))
+		}
+		multicall = deployment.Address
+	}
+
 	var callResponses []CallResponse
 
 	var multiCalls = make([]MultiCall2.CustomMulticall2Call, 0, len(calls))
@@ -143,7 +580,7 @@ func (caller *EthMultiCaller) ExecuteBalances(calls []Call, userAddress string)
 	}
 
 	// Perform multicall
-	resp, err := caller.Client.CallContract(context.Background(), ethereum.CallMsg{To: &caller.ContractAddress, Data: callData}, nil)
+	resp, err := caller.callContract(context.Background(), ethereum.CallMsg{To: &multicall, Data: callData}, nil)
 	if err != nil {
 		panic(err)
 	}
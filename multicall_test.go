@@ -0,0 +1,95 @@
+package go_eth_multicall
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestChunkCalls(t *testing.T) {
+	calls := bisectCalls(5)
+
+	tests := []struct {
+		size int
+		want []int
+	}{
+		{size: 0, want: []int{5}},
+		{size: -1, want: []int{5}},
+		{size: 5, want: []int{5}},
+		{size: 10, want: []int{5}},
+		{size: 2, want: []int{2, 2, 1}},
+		{size: 1, want: []int{1, 1, 1, 1, 1}},
+	}
+
+	for _, test := range tests {
+		chunks := chunkCalls(calls, test.size)
+
+		sizes := make([]int, len(chunks))
+		var joined []Call
+		for i, chunk := range chunks {
+			sizes[i] = len(chunk)
+			joined = append(joined, chunk...)
+		}
+		if !reflect.DeepEqual(sizes, test.want) {
+			t.Errorf("size %d: got chunks of %v, want %v", test.size, sizes, test.want)
+		}
+		if !reflect.DeepEqual(joined, calls) {
+			t.Errorf("size %d: chunks do not add up to the calls", test.size)
+		}
+	}
+}
+
+func TestDeduplicateCalls(t *testing.T) {
+	a := common.HexToAddress("0x0a")
+	b := common.HexToAddress("0x0b")
+	calls := []Call{
+		{Name: "first", Target: a, CallData: []byte{1}},
+		{Name: "other target", Target: b, CallData: []byte{1}},
+		{Name: "same as first", Target: a, CallData: []byte{1}},
+		{Name: "other data", Target: a, CallData: []byte{2}},
+		{Name: "same as other target", Target: b, CallData: []byte{1}},
+	}
+
+	unique, indexes := deduplicateCalls(calls)
+	if len(unique) != 3 || unique[0].Name != "first" || unique[1].Name != "other target" || unique[2].Name != "other data" {
+		t.Fatalf("got %+v", unique)
+	}
+	if want := []int{0, 1, 0, 2, 1}; !reflect.DeepEqual(indexes, want) {
+		t.Fatalf("got indexes %v, want %v", indexes, want)
+	}
+}
+
+func TestExecuteDeduplicated(t *testing.T) {
+	calls := []Call{
+		{Name: "a", Target: common.HexToAddress("0x0a"), CallData: []byte{1}},
+		{Name: "b", Target: common.HexToAddress("0x0a"), CallData: []byte{1}},
+		{Name: "c", Target: common.HexToAddress("0x0a"), CallData: []byte{2}},
+	}
+
+	responses, err := executeDeduplicated(calls, func(unique []Call) ([]CallResponse, error) {
+		if len(unique) != 2 {
+			t.Fatalf("executed %d calls, want 2", len(unique))
+		}
+		return []CallResponse{{Success: true, ReturnData: []byte{1}}, {Success: true, ReturnData: []byte{2}}}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if responses[0].ReturnData[0] != 1 || responses[1].ReturnData[0] != 1 || responses[2].ReturnData[0] != 2 {
+		t.Fatalf("got %+v", responses)
+	}
+
+	_, err = executeDeduplicated(calls, func(unique []Call) ([]CallResponse, error) {
+		return []CallResponse{{Success: true}}, nil
+	})
+	if err == nil {
+		t.Fatal("accepted fewer responses than calls")
+	}
+
+	failed := errors.New("failed")
+	if _, err := executeDeduplicated(calls, func(unique []Call) ([]CallResponse, error) { return nil, failed }); err != failed {
+		t.Fatalf("got %v, want %v", err, failed)
+	}
+}
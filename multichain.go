@@ -0,0 +1,122 @@
+package go_eth_multicall
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// ChainCall is a Call to execute on the chain with the given id
+type ChainCall struct {
+	ChainID uint64
+	Call
+}
+
+// MultiChainResult holds the outcome of calls spread over several chains. Responses[i] is the response to Calls[i].
+type MultiChainResult struct {
+	Calls     []ChainCall
+	Responses []CallResponse
+	// Chains holds the result set of every chain that succeeded, by chain id
+	Chains map[uint64]ResultSet
+	// Errors holds the error of every chain that failed, by chain id. The responses of its calls are left zero.
+	Errors map[uint64]error
+}
+
+// Map returns the responses of the chains that succeeded keyed by call name, like Execute does
+func (result MultiChainResult) Map() map[string]CallResponse {
+	results := make(map[string]CallResponse, len(result.Calls))
+	for i, call := range result.Calls {
+		if _, failed := result.Errors[call.ChainID]; !failed {
+			results[call.Name] = result.Responses[i]
+		}
+	}
+
+	return results
+}
+
+// MultiChainCaller routes calls tagged with a chain id to the EthMultiCaller of that chain, so one portfolio can be
+// read across chains in a single execution. Chains are added before executing, Add is not safe for concurrent use
+// with Execute.
+type MultiChainCaller struct {
+	callers map[uint64]*EthMultiCaller
+}
+
+func NewMultiChainCaller() *MultiChainCaller {
+	return &MultiChainCaller{callers: make(map[uint64]*EthMultiCaller)}
+}
+
+// Add registers the caller of a chain, replacing any previous one
+func (multi *MultiChainCaller) Add(chainID uint64, caller *EthMultiCaller) {
+	multi.callers[chainID] = caller
+}
+
+// Caller returns the caller of a chain
+func (multi *MultiChainCaller) Caller(chainID uint64) (*EthMultiCaller, bool) {
+	caller, ok := multi.callers[chainID]
+	return caller, ok
+}
+
+// Execute groups the calls by chain and executes the groups in parallel, each at the latest block of its chain.
+// A chain failing, or missing, only fails its own calls: the error is reported in Errors and the other chains
+// still return their responses. The chain id of every caller is checked against the one it was added with.
+func (multi *MultiChainCaller) Execute(ctx context.Context, calls []ChainCall) MultiChainResult {
+	result := MultiChainResult{
+		Calls:     calls,
+		Responses: make([]CallResponse, len(calls)),
+		Chains:    make(map[uint64]ResultSet),
+		Errors:    make(map[uint64]error),
+	}
+
+	groups := make(map[uint64][]int)
+	for i, call := range calls {
+		groups[call.ChainID] = append(groups[call.ChainID], i)
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for chainID, indexes := range groups {
+		wg.Add(1)
+		go func(chainID uint64, indexes []int) {
+			defer wg.Done()
+
+			set, err := multi.executeChain(ctx, chainID, calls, indexes)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				result.Errors[chainID] = err
+				return
+			}
+
+			result.Chains[chainID] = set
+			for i, index := range indexes {
+				result.Responses[index] = set.Responses[i]
+			}
+		}(chainID, indexes)
+	}
+	wg.Wait()
+
+	return result
+}
+
+func (multi *MultiChainCaller) executeChain(ctx context.Context, chainID uint64, calls []ChainCall, indexes []int) (ResultSet, error) {
+	caller, ok := multi.callers[chainID]
+	if !ok {
+		return ResultSet{}, fmt.Errorf("multicall: no caller for chain %d", chainID)
+	}
+
+	actualChainID, err := caller.chainIDUint64(ctx)
+	if err != nil {
+		return ResultSet{}, err
+	}
+	if actualChainID != chainID {
+		return ResultSet{}, fmt.Errorf("multicall: caller of chain %d is connected to chain %d", chainID, actualChainID)
+	}
+
+	chainCalls := make([]Call, len(indexes))
+	for i, index := range indexes {
+		chainCalls[i] = calls[index].Call
+	}
+
+	return caller.ExecuteWithBlock(ctx, chainCalls, nil)
+}
@@ -0,0 +1,311 @@
+package go_eth_multicall
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+// ErrUnverified is returned along with the result set when some reads could not be proven against the state root
+var ErrUnverified = errors.New("multicall: unverified reads")
+
+// ProofRead is a read of account state that can be proven against the state root of a block: the native balance of
+// Account, or the raw value of one of its storage slots
+type ProofRead struct {
+	Name    string
+	Account common.Address
+	// Slot is the storage slot to read, nil to read the native balance
+	Slot *common.Hash
+}
+
+// BalanceRead reads the native balance of an account
+func BalanceRead(name string, account common.Address) ProofRead {
+	return ProofRead{Name: name, Account: account}
+}
+
+// StorageRead reads the raw value of a storage slot of an account
+func StorageRead(name string, account common.Address, slot common.Hash) ProofRead {
+	return ProofRead{Name: name, Account: account, Slot: &slot}
+}
+
+// VerifiedResponse is the outcome of a ProofRead. ReturnData holds the value as a 32 bytes word, like a call to
+// balanceOf or a public uint256 would return it.
+type VerifiedResponse struct {
+	CallResponse
+	// Verified tells whether the value was proven against the state root of the block
+	Verified bool
+	// Reason is why the value could not be verified
+	Reason string
+}
+
+// VerifiedResultSet holds the outcome of proven reads at a block. Responses[i] is the outcome of Reads[i].
+type VerifiedResultSet struct {
+	BlockNumber uint64
+	BlockHash   common.Hash
+	StateRoot   common.Hash
+	Reads       []ProofRead
+	Responses   []VerifiedResponse
+}
+
+// Verified reports whether every read was verified
+func (set VerifiedResultSet) Verified() bool {
+	for _, response := range set.Responses {
+		if !response.Verified {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Map returns the responses keyed by read name
+func (set VerifiedResultSet) Map() map[string]VerifiedResponse {
+	results := make(map[string]VerifiedResponse, len(set.Reads))
+	for i, read := range set.Reads {
+		results[read.Name] = set.Responses[i]
+	}
+
+	return results
+}
+
+// accountResult is the answer to eth_getProof
+type accountResult struct {
+	AccountProof []hexutil.Bytes `json:"accountProof"`
+	Balance      *hexutil.Big    `json:"balance"`
+	CodeHash     common.Hash     `json:"codeHash"`
+	Nonce        hexutil.Uint64  `json:"nonce"`
+	StorageHash  common.Hash     `json:"storageHash"`
+	StorageProof []storageResult `json:"storageProof"`
+}
+
+type storageResult struct {
+	Value *hexutil.Big    `json:"value"`
+	Proof []hexutil.Bytes `json:"proof"`
+}
+
+// ExecuteVerified reads native balances and storage slots at the given block, nil meaning the latest block, through
+// eth_getProof, and verifies the proofs against the state root of the block header. Every read is marked verified
+// or not, and ErrUnverified is returned along with the result set when some are not.
+//
+// The header is identified by its hash, which is computed locally, so the only thing left to trust is BlockHash.
+// Compare it with another source, e.g. a quorum of providers, for a fully trust-minimized read.
+func (caller *EthMultiCaller) ExecuteVerified(ctx context.Context, reads []ProofRead, blockNumber *big.Int) (VerifiedResultSet, error) {
+	var set VerifiedResultSet
+	err := caller.route(ctx, func(ctx context.Context) (err error) {
+		set, err = caller.executeVerified(ctx, reads, blockNumber)
+		return err
+	})
+	if err != nil {
+		return VerifiedResultSet{}, err
+	}
+	if !set.Verified() {
+		return set, ErrUnverified
+	}
+
+	return set, nil
+}
+
+func (caller *EthMultiCaller) executeVerified(ctx context.Context, reads []ProofRead, blockNumber *big.Int) (VerifiedResultSet, error) {
+	rpcClient := caller.rpcClient(ctx)
+	if rpcClient == nil {
+		return VerifiedResultSet{}, ErrNoRPCClient
+	}
+
+	header, err := caller.headerByNumber(ctx, blockNumber)
+	if err != nil {
+		return VerifiedResultSet{}, err
+	}
+	set := VerifiedResultSet{
+		BlockNumber: header.Number.Uint64(),
+		BlockHash:   header.Hash(),
+		StateRoot:   header.Root,
+		Reads:       reads,
+		Responses:   make([]VerifiedResponse, len(reads)),
+	}
+
+	// One eth_getProof per account, covering all of its slots
+	var accounts []common.Address
+	slots := make(map[common.Address][]common.Hash)
+	for _, read := range reads {
+		if _, ok := slots[read.Account]; !ok {
+			accounts = append(accounts, read.Account)
+			slots[read.Account] = []common.Hash{}
+		}
+		if read.Slot != nil {
+			slots[read.Account] = append(slots[read.Account], *read.Slot)
+		}
+	}
+
+	results := make([]accountResult, len(accounts))
+	batch := make([]rpc.BatchElem, len(accounts))
+	for i, account := range accounts {
+		batch[i] = rpc.BatchElem{
+			Method: "eth_getProof",
+			Args:   []interface{}{account, slots[account], blockArg(nil, &set.BlockHash)},
+			Result: &results[i],
+		}
+	}
+
+	err = caller.withRetry(ctx, func() error {
+		if err := caller.limiter(ctx).Wait(ctx, "eth_getProof", len(batch)); err != nil {
+			return err
+		}
+		for i := range batch {
+			batch[i].Error = nil
+		}
+		if err := rpcClient.BatchCallContext(ctx, batch); err != nil {
+			return err
+		}
+
+		for i := range batch {
+			if batch[i].Error != nil {
+				return batch[i].Error
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return VerifiedResultSet{}, err
+	}
+
+	byAccount := make(map[common.Address]int, len(accounts))
+	for i, account := range accounts {
+		byAccount[account] = i
+		if len(results[i].StorageProof) != len(slots[account]) {
+			return VerifiedResultSet{}, fmt.Errorf("multicall: got %d storage proofs for %d slots of %s", len(results[i].StorageProof), len(slots[account]), account.Hex())
+		}
+	}
+
+	// Slots are answered in the order they were asked for
+	next := make(map[common.Address]int, len(accounts))
+	for i, read := range reads {
+		result := results[byAccount[read.Account]]
+		accountErr := verifyAccount(set.StateRoot, read.Account, result)
+
+		if read.Slot == nil {
+			set.Responses[i] = verifiedResponse((*big.Int)(result.Balance), accountErr)
+			continue
+		}
+
+		proof := result.StorageProof[next[read.Account]]
+		next[read.Account]++
+		if accountErr != nil {
+			set.Responses[i] = verifiedResponse((*big.Int)(proof.Value), fmt.Errorf("storage root: %w", accountErr))
+			continue
+		}
+		set.Responses[i] = verifiedResponse((*big.Int)(proof.Value), verifyStorage(result.StorageHash, *read.Slot, proof))
+	}
+
+	return set, nil
+}
+
+func verifiedResponse(value *big.Int, err error) VerifiedResponse {
+	if value == nil {
+		value = new(big.Int)
+	}
+
+	response := VerifiedResponse{CallResponse: CallResponse{Success: true, ReturnData: common.BigToHash(value).Bytes()}, Verified: err == nil}
+	if err != nil {
+		response.Reason = err.Error()
+	}
+
+	return response
+}
+
+// proofDB holds the nodes of a Merkle-Patricia proof by hash, as trie.VerifyProof looks them up
+func proofDB(proof []hexutil.Bytes) *memorydb.Database {
+	db := memorydb.New()
+	for _, node := range proof {
+		db.Put(crypto.Keccak256(node), node)
+	}
+
+	return db
+}
+
+// verifyAccount checks the claimed state of the account against the state root
+func verifyAccount(root common.Hash, address common.Address, result accountResult) error {
+	value, err := trie.VerifyProof(root, crypto.Keccak256(address.Bytes()), proofDB(result.AccountProof))
+	if err != nil {
+		return fmt.Errorf("invalid account proof: %w", err)
+	}
+
+	balance := (*big.Int)(result.Balance)
+	if balance == nil {
+		balance = new(big.Int)
+	}
+
+	if value == nil {
+		// The proof shows the account does not exist, it must be claimed empty
+		if balance.Sign() != 0 || result.Nonce != 0 {
+			return errors.New("account proven absent but claimed with a balance or nonce")
+		}
+		if result.StorageHash != types.EmptyRootHash && result.StorageHash != (common.Hash{}) {
+			return errors.New("account proven absent but claimed with a storage")
+		}
+		return nil
+	}
+
+	var account types.StateAccount
+	if err := rlp.DecodeBytes(value, &account); err != nil {
+		return fmt.Errorf("invalid account in proof: %w", err)
+	}
+
+	switch {
+	case account.Balance.Cmp(balance) != 0:
+		return fmt.Errorf("balance %s claimed, %s proven", balance, account.Balance)
+	case account.Nonce != uint64(result.Nonce):
+		return fmt.Errorf("nonce %d claimed, %d proven", result.Nonce, account.Nonce)
+	case account.Root != result.StorageHash:
+		return fmt.Errorf("storage hash %s claimed, %s proven", result.StorageHash.Hex(), account.Root.Hex())
+	case !bytes.Equal(account.CodeHash, result.CodeHash.Bytes()):
+		return fmt.Errorf("code hash %s claimed, %s proven", result.CodeHash.Hex(), common.BytesToHash(account.CodeHash).Hex())
+	}
+
+	return nil
+}
+
+// verifyStorage checks the claimed value of the slot against the storage root of a verified account
+func verifyStorage(root common.Hash, slot common.Hash, result storageResult) error {
+	claimed := (*big.Int)(result.Value)
+	if claimed == nil {
+		claimed = new(big.Int)
+	}
+
+	if root == types.EmptyRootHash || root == (common.Hash{}) {
+		if claimed.Sign() != 0 {
+			return errors.New("value claimed in an empty storage")
+		}
+		return nil
+	}
+
+	value, err := trie.VerifyProof(root, crypto.Keccak256(slot.Bytes()), proofDB(result.Proof))
+	if err != nil {
+		return fmt.Errorf("invalid storage proof: %w", err)
+	}
+
+	proven := new(big.Int)
+	if value != nil {
+		var content []byte
+		if err := rlp.DecodeBytes(value, &content); err != nil {
+			return fmt.Errorf("invalid storage value in proof: %w", err)
+		}
+		proven.SetBytes(content)
+	}
+	if proven.Cmp(claimed) != 0 {
+		return fmt.Errorf("value %s claimed, %s proven", claimed, proven)
+	}
+
+	return nil
+}
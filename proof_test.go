@@ -0,0 +1,263 @@
+package go_eth_multicall
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+var (
+	proofHolder   = common.HexToAddress("0x00000000219ab540356cBB839Cbe05303d7705Fa")
+	proofContract = common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+	proofAbsent   = common.HexToAddress("0x000000000000000000000000000000000000dEaD")
+	proofSlot     = common.HexToHash("0x02")
+	proofEmpty    = common.HexToHash("0x03")
+)
+
+// proofFixture builds a state with a funded account and a contract holding storage, and answers eth_getProof for
+// it the way geth does
+type proofFixture struct {
+	root  common.Hash
+	state *state.StateDB
+}
+
+func newProofFixture(t *testing.T) *proofFixture {
+	database := state.NewDatabase(rawdb.NewMemoryDatabase())
+	statedb, err := state.New(common.Hash{}, database, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	statedb.SetBalance(proofHolder, big.NewInt(4e18))
+	statedb.SetNonce(proofHolder, 7)
+	statedb.SetNonce(proofContract, 1)
+	statedb.SetCode(proofContract, []byte{0x60, 0x00, 0x60, 0x00, 0xfd})
+	statedb.SetState(proofContract, common.HexToHash("0x01"), common.HexToHash("0x1234"))
+	statedb.SetState(proofContract, proofSlot, common.BigToHash(big.NewInt(1e12)))
+	// Enough accounts and slots for the proofs to span several trie nodes
+	for i := int64(0); i < 64; i++ {
+		statedb.SetBalance(common.BigToAddress(big.NewInt(0x1000+i)), big.NewInt(i+1))
+		statedb.SetState(proofContract, common.BigToHash(big.NewInt(0x1000+i)), common.BigToHash(big.NewInt(i+1)))
+	}
+
+	root, err := statedb.Commit(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if statedb, err = state.New(root, database, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	return &proofFixture{root: root, state: statedb}
+}
+
+func (fixture *proofFixture) getProof(t *testing.T, account common.Address, slots ...common.Hash) accountResult {
+	proof, err := fixture.state.GetProof(account)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := accountResult{
+		Balance:     (*hexutil.Big)(fixture.state.GetBalance(account)),
+		CodeHash:    fixture.state.GetCodeHash(account),
+		Nonce:       hexutil.Uint64(fixture.state.GetNonce(account)),
+		StorageHash: types.EmptyRootHash,
+	}
+	if storage := fixture.state.StorageTrie(account); storage != nil {
+		result.StorageHash = storage.Hash()
+	}
+	for _, node := range proof {
+		result.AccountProof = append(result.AccountProof, node)
+	}
+
+	for _, slot := range slots {
+		proof, err := fixture.state.GetStorageProof(account, slot)
+		if err != nil {
+			t.Fatal(err)
+		}
+		storage := storageResult{Value: (*hexutil.Big)(fixture.state.GetState(account, slot).Big())}
+		for _, node := range proof {
+			storage.Proof = append(storage.Proof, node)
+		}
+		result.StorageProof = append(result.StorageProof, storage)
+	}
+
+	return result
+}
+
+func TestVerifyAccount(t *testing.T) {
+	fixture := newProofFixture(t)
+
+	tests := []struct {
+		name    string
+		account common.Address
+		tamper  func(result *accountResult)
+		fails   string
+	}{
+		{name: "funded account", account: proofHolder},
+		{name: "contract", account: proofContract},
+		{name: "absent account", account: proofAbsent},
+		{name: "balance", account: proofHolder, tamper: func(result *accountResult) {
+			result.Balance = (*hexutil.Big)(big.NewInt(5e18))
+		}, fails: "balance"},
+		{name: "nonce", account: proofHolder, tamper: func(result *accountResult) { result.Nonce++ }, fails: "nonce"},
+		{name: "storage hash", account: proofContract, tamper: func(result *accountResult) {
+			result.StorageHash = types.EmptyRootHash
+		}, fails: "storage hash"},
+		{name: "code hash", account: proofContract, tamper: func(result *accountResult) {
+			result.CodeHash = fixture.state.GetCodeHash(proofHolder)
+		}, fails: "code hash"},
+		{name: "absent account claimed funded", account: proofAbsent, tamper: func(result *accountResult) {
+			result.Balance = (*hexutil.Big)(big.NewInt(1))
+		}, fails: "absent"},
+		{name: "missing proof node", account: proofHolder, tamper: func(result *accountResult) {
+			result.AccountProof = result.AccountProof[:len(result.AccountProof)-1]
+		}, fails: "invalid account proof"},
+		{name: "altered proof node", account: proofHolder, tamper: func(result *accountResult) {
+			node := append(hexutil.Bytes{}, result.AccountProof[0]...)
+			node[len(node)-1] ^= 0xff
+			result.AccountProof[0] = node
+		}, fails: "invalid account proof"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result := fixture.getProof(t, test.account)
+			if len(result.AccountProof) < 2 {
+				t.Fatalf("got a proof of %d nodes, the fixture is too small", len(result.AccountProof))
+			}
+			if test.tamper != nil {
+				test.tamper(&result)
+			}
+
+			err := verifyAccount(fixture.root, test.account, result)
+			if test.fails == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), test.fails) {
+				t.Fatalf("got %v, want an error about %s", err, test.fails)
+			}
+		})
+	}
+
+	// A proof against another block does not verify
+	if err := verifyAccount(common.HexToHash("0x01"), proofHolder, fixture.getProof(t, proofHolder)); err == nil {
+		t.Fatal("verified a proof against another state root")
+	}
+}
+
+func TestVerifyStorage(t *testing.T) {
+	fixture := newProofFixture(t)
+
+	tests := []struct {
+		name    string
+		account common.Address
+		slot    common.Hash
+		claim   *big.Int
+		fails   string
+	}{
+		{name: "set slot", account: proofContract, slot: proofSlot},
+		{name: "unset slot", account: proofContract, slot: proofEmpty},
+		{name: "account without storage", account: proofHolder, slot: proofSlot},
+		{name: "wrong value", account: proofContract, slot: proofSlot, claim: big.NewInt(1e13), fails: "claimed"},
+		{name: "value in unset slot", account: proofContract, slot: proofEmpty, claim: big.NewInt(1), fails: "claimed"},
+		{name: "value in empty storage", account: proofHolder, slot: proofSlot, claim: big.NewInt(1), fails: "empty storage"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result := fixture.getProof(t, test.account, test.slot)
+			if err := verifyAccount(fixture.root, test.account, result); err != nil {
+				t.Fatal(err)
+			}
+
+			storage := result.StorageProof[0]
+			if test.claim != nil {
+				storage.Value = (*hexutil.Big)(test.claim)
+			}
+
+			err := verifyStorage(result.StorageHash, test.slot, storage)
+			if test.fails == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), test.fails) {
+				t.Fatalf("got %v, want an error about %s", err, test.fails)
+			}
+		})
+	}
+}
+
+func TestVerifiedResponse(t *testing.T) {
+	response := verifiedResponse(big.NewInt(1e12), nil)
+	if !response.Verified || !response.Success || new(big.Int).SetBytes(response.ReturnData).Int64() != 1e12 || len(response.ReturnData) != 32 {
+		t.Fatalf("got %+v", response)
+	}
+
+	unverified := errors.New("value 1 claimed, 2 proven")
+	response = verifiedResponse(nil, unverified)
+	if response.Verified || response.Reason != unverified.Error() || len(response.ReturnData) != 32 {
+		t.Fatalf("got %+v", response)
+	}
+}
+
+func TestExecuteVerified(t *testing.T) {
+	fixture := newProofFixture(t)
+	node, caller := newFakeNode(t)
+	node.head.Root = fixture.root
+	node.proof = func(account common.Address, slots []common.Hash) accountResult {
+		result := fixture.getProof(t, account, slots...)
+		if account == proofContract {
+			// The node lies about the second slot asked for
+			result.StorageProof[1].Value = (*hexutil.Big)(big.NewInt(42))
+		}
+		return result
+	}
+
+	reads := []ProofRead{
+		BalanceRead("holder", proofHolder),
+		StorageRead("slot", proofContract, proofSlot),
+		StorageRead("lied", proofContract, proofEmpty),
+		BalanceRead("absent", proofAbsent),
+	}
+	set, err := caller.ExecuteVerified(context.Background(), reads, nil)
+	if !errors.Is(err, ErrUnverified) {
+		t.Fatalf("got %v, want ErrUnverified", err)
+	}
+	if set.StateRoot != fixture.root || set.BlockHash != node.headHash() {
+		t.Fatalf("got root %s at %s", set.StateRoot.Hex(), set.BlockHash.Hex())
+	}
+	if got := node.count("eth_getProof"); got != 3 {
+		t.Errorf("got %d eth_getProof, want one per account", got)
+	}
+
+	responses := set.Map()
+	want := map[string]struct {
+		value    int64
+		verified bool
+	}{
+		"holder": {4e18, true},
+		"slot":   {1e12, true},
+		"lied":   {42, false},
+		"absent": {0, true},
+	}
+	for name, want := range want {
+		response := responses[name]
+		if value := new(big.Int).SetBytes(response.ReturnData).Int64(); value != want.value || response.Verified != want.verified {
+			t.Errorf("%s: got %d verified %v (%s), want %d verified %v", name, value, response.Verified, response.Reason, want.value, want.verified)
+		}
+	}
+}
@@ -0,0 +1,228 @@
+package go_eth_multicall
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ErrNoQuorum is returned when some calls did not get the same response from enough providers
+var ErrNoQuorum = errors.New("multicall: no quorum")
+
+// QuorumOptions configures a quorum read
+type QuorumOptions struct {
+	// Quorum is the number of providers that must return the same response for it to be accepted, a majority of
+	// the endpoints when zero. A response is not accepted when another response reaches the quorum as well.
+	Quorum int
+}
+
+// Dissent is a provider response that differs from the accepted one, or one of the competing responses when no
+// response was accepted
+type Dissent struct {
+	Provider string
+	Response CallResponse
+}
+
+// QuorumResponse is the outcome of a call across the providers
+type QuorumResponse struct {
+	// CallResponse is the accepted response, zero when Agreed is false
+	CallResponse
+	Agreed bool
+	// Votes is the number of providers that returned the accepted response, or the most common one
+	Votes    int
+	Dissents []Dissent
+}
+
+// ProviderResult tells how the execution went on one provider
+type ProviderResult struct {
+	Provider  string
+	BlockHash common.Hash
+	Err       error
+}
+
+// QuorumResultSet holds the outcome of a quorum read. Responses[i] is the outcome of Calls[i].
+type QuorumResultSet struct {
+	BlockNumber uint64
+	Calls       []Call
+	Responses   []QuorumResponse
+	Providers   []ProviderResult
+}
+
+// Agreed reports whether every call reached the quorum
+func (set QuorumResultSet) Agreed() bool {
+	for _, response := range set.Responses {
+		if !response.Agreed {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ExecuteQuorum runs the calls at the same block on every endpoint of the pool and accepts the response of a call
+// only when Quorum providers returned it. The block defaults to the lowest head among the providers, so all of them
+// have it. Each provider resolves the block number on its own, the hash it executed at is reported alongside.
+//
+// Calls that do not reach the quorum are reported with the competing responses and ErrNoQuorum is returned along
+// with the result set. A provider failing to execute counts as a provider that does not agree.
+func (caller *EthMultiCaller) ExecuteQuorum(ctx context.Context, calls []Call, blockNumber *big.Int, options QuorumOptions) (QuorumResultSet, error) {
+	if caller.Endpoints == nil {
+		return QuorumResultSet{}, errors.New("multicall: quorum reads need an endpoint pool")
+	}
+
+	endpoints := caller.Endpoints.endpoints
+	quorum := options.Quorum
+	if quorum <= 0 {
+		quorum = len(endpoints)/2 + 1
+	}
+	if quorum > len(endpoints) {
+		return QuorumResultSet{}, fmt.Errorf("multicall: quorum of %d with %d endpoints", quorum, len(endpoints))
+	}
+
+	if blockNumber == nil {
+		lowest, err := caller.lowestHead(ctx, endpoints)
+		if err != nil {
+			return QuorumResultSet{}, err
+		}
+		blockNumber = new(big.Int).SetUint64(lowest)
+	}
+
+	set := QuorumResultSet{BlockNumber: blockNumber.Uint64(), Calls: calls, Providers: make([]ProviderResult, len(endpoints))}
+	responses := make([][]CallResponse, len(endpoints))
+
+	var wg sync.WaitGroup
+	for i, endpoint := range endpoints {
+		wg.Add(1)
+		go func(i int, endpoint *poolEndpoint) {
+			defer wg.Done()
+
+			set.Providers[i].Provider = endpoint.Name
+			endpointCtx := context.WithValue(ctx, endpointKey{}, endpoint)
+			set.Providers[i].Err = caller.withRetry(ctx, func() error {
+				header, err := caller.headerByNumber(endpointCtx, blockNumber)
+				if err != nil {
+					return err
+				}
+				set.Providers[i].BlockHash = header.Hash()
+
+				// Cache and Store are shared by the providers, each one has to answer for itself
+				responses[i], err = executeDeduplicated(calls, func(unique []Call) ([]CallResponse, error) {
+					return caller.tryAggregateAtHash(endpointCtx, unique, header.Hash())
+				})
+				return err
+			})
+		}(i, endpoint)
+	}
+	wg.Wait()
+
+	executed := 0
+	for _, provider := range set.Providers {
+		if provider.Err == nil {
+			executed++
+		}
+	}
+	if executed < quorum {
+		return set, fmt.Errorf("%w: %d of %d providers executed the calls, %d needed", ErrNoQuorum, executed, len(endpoints), quorum)
+	}
+
+	set.Responses = make([]QuorumResponse, len(calls))
+	agreed := true
+	for i := range calls {
+		set.Responses[i] = vote(set.Providers, responses, i, quorum)
+		agreed = agreed && set.Responses[i].Agreed
+	}
+	if !agreed {
+		return set, ErrNoQuorum
+	}
+
+	return set, nil
+}
+
+// vote tallies the responses of the providers to the i-th call. With a quorum of half the providers or less, two
+// responses can both reach it, the call is then not agreed.
+func vote(providers []ProviderResult, responses [][]CallResponse, i int, quorum int) QuorumResponse {
+	votes := make(map[string]int)
+	best := ""
+	for provider := range providers {
+		if providers[provider].Err != nil {
+			continue
+		}
+
+		key := responseKey(responses[provider][i])
+		votes[key]++
+		if votes[key] > votes[best] {
+			best = key
+		}
+	}
+
+	reached := 0
+	for _, count := range votes {
+		if count >= quorum {
+			reached++
+		}
+	}
+
+	result := QuorumResponse{Votes: votes[best], Agreed: reached == 1}
+	for provider := range providers {
+		if providers[provider].Err != nil {
+			continue
+		}
+
+		response := responses[provider][i]
+		if result.Agreed && responseKey(response) == best {
+			result.CallResponse = response
+			continue
+		}
+		result.Dissents = append(result.Dissents, Dissent{Provider: providers[provider].Provider, Response: response})
+	}
+
+	return result
+}
+
+// responseKey identifies a response by its outcome and return data
+func responseKey(response CallResponse) string {
+	if response.Success {
+		return "1" + string(response.ReturnData)
+	}
+
+	return "0" + string(response.ReturnData)
+}
+
+// lowestHead returns the lowest latest block number among the endpoints that answer
+func (caller *EthMultiCaller) lowestHead(ctx context.Context, endpoints []*poolEndpoint) (uint64, error) {
+	var mu sync.Mutex
+	var lowest uint64
+	var found bool
+	var lastErr error
+
+	var wg sync.WaitGroup
+	for _, endpoint := range endpoints {
+		wg.Add(1)
+		go func(endpoint *poolEndpoint) {
+			defer wg.Done()
+
+			header, err := caller.headerByNumber(context.WithValue(ctx, endpointKey{}, endpoint), nil)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				lastErr = err
+				return
+			}
+			if !found || header.Number.Uint64() < lowest {
+				lowest, found = header.Number.Uint64(), true
+			}
+		}(endpoint)
+	}
+	wg.Wait()
+
+	if !found {
+		return 0, lastErr
+	}
+
+	return lowest, nil
+}
@@ -0,0 +1,82 @@
+package go_eth_multicall
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	MultiCall2 "github.com/truongpx396/go-eth-multicall/contracts/MultiCall"
+)
+
+func TestExecuteQuorumBypassesCache(t *testing.T) {
+	nodes, caller := newFakePool(t, "honest", "lying")
+	cache := caller.EnableCache()
+	nodes[1].answer = func(attempt int, call MultiCall2.Multicall2Call) (fakeResult, bool) {
+		return fakeResult{Success: true, ReturnData: []byte{0xff}}, true
+	}
+
+	calls := []Call{{Name: "a", Target: common.HexToAddress("0x02"), CallData: []byte{1, 2, 3, 4}}}
+	// A response cached at the head must not stand in for what the providers answer
+	head := nodes[0].headHash()
+	cache.advance(1, head)
+	cache.put(newCacheKey(1, head, calls[0]), CallResponse{Success: true, ReturnData: []byte{0xee}})
+
+	set, err := caller.ExecuteQuorum(context.Background(), calls, nil, QuorumOptions{Quorum: 2})
+	if !errors.Is(err, ErrNoQuorum) {
+		t.Fatalf("got %v, want ErrNoQuorum", err)
+	}
+	if len(set.Responses) != 1 || set.Responses[0].Agreed || len(set.Responses[0].Dissents) != 2 {
+		t.Fatalf("got %+v, want a disagreement", set.Responses)
+	}
+	for _, node := range nodes {
+		if node.count("eth_call") != 1 {
+			t.Errorf("provider executed %d eth_calls, want 1", node.count("eth_call"))
+		}
+	}
+}
+
+func TestVote(t *testing.T) {
+	a := CallResponse{Success: true, ReturnData: []byte{0xa}}
+	b := CallResponse{Success: true, ReturnData: []byte{0xb}}
+	reverted := CallResponse{Success: false, ReturnData: []byte{0xa}}
+
+	tests := []struct {
+		name      string
+		responses []CallResponse
+		failed    []bool
+		quorum    int
+		agreed    bool
+		votes     int
+		dissents  int
+	}{
+		{name: "unanimous", responses: []CallResponse{a, a, a}, quorum: 2, agreed: true, votes: 3},
+		{name: "majority", responses: []CallResponse{a, b, a}, quorum: 2, agreed: true, votes: 2, dissents: 1},
+		{name: "split", responses: []CallResponse{a, b, reverted}, quorum: 2, votes: 1, dissents: 3},
+		{name: "success differs", responses: []CallResponse{a, reverted}, quorum: 2, votes: 1, dissents: 2},
+		{name: "tie at quorum", responses: []CallResponse{a, a, b, b}, quorum: 2, votes: 2, dissents: 4},
+		{name: "failed provider", responses: []CallResponse{a, b, a}, failed: []bool{false, false, true}, quorum: 2, votes: 1, dissents: 2},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			providers := make([]ProviderResult, len(test.responses))
+			responses := make([][]CallResponse, len(test.responses))
+			for i, response := range test.responses {
+				providers[i].Provider = string(rune('a' + i))
+				if test.failed != nil && test.failed[i] {
+					providers[i].Err = errors.New("failed")
+				}
+				responses[i] = []CallResponse{response}
+			}
+
+			result := vote(providers, responses, 0, test.quorum)
+			if result.Agreed != test.agreed || result.Votes != test.votes || len(result.Dissents) != test.dissents {
+				t.Fatalf("got agreed %v with %d votes and %d dissents, want %v with %d and %d", result.Agreed, result.Votes, len(result.Dissents), test.agreed, test.votes, test.dissents)
+			}
+			if result.Agreed && string(result.ReturnData) != string(a.ReturnData) {
+				t.Fatalf("accepted %x", result.ReturnData)
+			}
+		})
+	}
+}
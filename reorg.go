@@ -0,0 +1,248 @@
+package go_eth_multicall
+
+import (
+	"context"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// DefaultReorgDepth is how many blocks behind the head a ReorgTracker keeps watching, deeper reorgs are not expected
+// on proof of stake Ethereum once blocks are finalized
+const DefaultReorgDepth = 64
+
+// Retraction tells that result sets were read at a block that is no longer part of the canonical chain
+type Retraction struct {
+	BlockNumber uint64
+	// BlockHash is the orphaned block the result sets were read at
+	BlockHash common.Hash
+	// CanonicalHash is the block that replaced it
+	CanonicalHash common.Hash
+	ResultSets    []ResultSet
+}
+
+type trackedBlock struct {
+	hash common.Hash
+	sets []ResultSet
+}
+
+// ReorgTracker remembers the blocks of the result sets it hands out and emits a Retraction when one of those blocks
+// is replaced by a reorg, so consumers storing snapshots know which ones came from an orphaned block.
+//
+// Result sets get tracked by executing through the tracker or by passing them to Track. Reorgs are detected when
+// a tracked result set does not extend the previous one and on every Check, which Run does at each new head.
+// Retractions should be consumed: when the channel is full, the oldest retraction is dropped to make room.
+type ReorgTracker struct {
+	caller      *EthMultiCaller
+	depth       uint64
+	retractions chan Retraction
+
+	mu     sync.Mutex
+	blocks map[uint64][]*trackedBlock
+	head   uint64
+}
+
+// NewReorgTracker returns a tracker watching the result sets of up to depth blocks behind the head,
+// DefaultReorgDepth when zero
+func NewReorgTracker(caller *EthMultiCaller, depth uint64) *ReorgTracker {
+	if depth == 0 {
+		depth = DefaultReorgDepth
+	}
+
+	return &ReorgTracker{
+		caller:      caller,
+		depth:       depth,
+		retractions: make(chan Retraction, 16),
+		blocks:      make(map[uint64][]*trackedBlock),
+	}
+}
+
+// Retractions is the channel the retractions are emitted on
+func (tracker *ReorgTracker) Retractions() <-chan Retraction {
+	return tracker.retractions
+}
+
+// Execute performs the multicall at the given block through tryBlockAndAggregate and tracks the result set
+func (tracker *ReorgTracker) Execute(ctx context.Context, calls []Call, blockNumber *big.Int) (ResultSet, error) {
+	set, err := tracker.caller.ExecuteWithBlock(ctx, calls, blockNumber)
+	if err != nil {
+		return ResultSet{}, err
+	}
+
+	tracker.Track(set)
+	return set, nil
+}
+
+// Track records a result set. When its parent hash contradicts the block tracked just below it, that block was
+// orphaned and is retracted right away.
+func (tracker *ReorgTracker) Track(set ResultSet) {
+	var retractions []Retraction
+
+	tracker.mu.Lock()
+	if set.BlockNumber > tracker.head {
+		tracker.head = set.BlockNumber
+	}
+	if tracker.head >= tracker.depth && set.BlockNumber < tracker.head-tracker.depth {
+		tracker.mu.Unlock()
+		return
+	}
+
+	block := tracker.blockLocked(set)
+	block.sets = append(block.sets, set)
+
+	if set.BlockNumber > 0 && set.ParentHash != (common.Hash{}) {
+		retractions = tracker.retractLocked(set.BlockNumber-1, set.ParentHash)
+	}
+	tracker.pruneLocked()
+	tracker.mu.Unlock()
+
+	tracker.emit(retractions)
+}
+
+func (tracker *ReorgTracker) blockLocked(set ResultSet) *trackedBlock {
+	for _, block := range tracker.blocks[set.BlockNumber] {
+		if block.hash == set.BlockHash {
+			return block
+		}
+	}
+
+	block := &trackedBlock{hash: set.BlockHash}
+	tracker.blocks[set.BlockNumber] = append(tracker.blocks[set.BlockNumber], block)
+	return block
+}
+
+// retractLocked drops the tracked blocks at a height that are not the canonical one, returning their retractions
+func (tracker *ReorgTracker) retractLocked(number uint64, canonical common.Hash) []Retraction {
+	var retractions []Retraction
+	var kept []*trackedBlock
+
+	for _, block := range tracker.blocks[number] {
+		if block.hash == canonical {
+			kept = append(kept, block)
+			continue
+		}
+		retractions = append(retractions, Retraction{BlockNumber: number, BlockHash: block.hash, CanonicalHash: canonical, ResultSets: block.sets})
+	}
+
+	if len(kept) == 0 {
+		delete(tracker.blocks, number)
+	} else {
+		tracker.blocks[number] = kept
+	}
+
+	return retractions
+}
+
+// pruneLocked forgets the blocks that are too deep to be reorganized
+func (tracker *ReorgTracker) pruneLocked() {
+	if tracker.head < tracker.depth {
+		return
+	}
+
+	for number := range tracker.blocks {
+		if number < tracker.head-tracker.depth {
+			delete(tracker.blocks, number)
+		}
+	}
+}
+
+// emit sends the retractions without blocking, dropping the oldest ones the consumer did not pick up yet
+func (tracker *ReorgTracker) emit(retractions []Retraction) {
+	for _, retraction := range retractions {
+		for sent := false; !sent; {
+			select {
+			case tracker.retractions <- retraction:
+				sent = true
+			default:
+				select {
+				case dropped := <-tracker.retractions:
+					log.Warn("Reorg retractions are not consumed, dropping the oldest", "block", dropped.BlockNumber, "hash", dropped.BlockHash)
+				default:
+				}
+			}
+		}
+	}
+}
+
+// Check compares every tracked block with the canonical chain and retracts the ones that were replaced. The
+// canonical chain is walked down from the head through parent hashes, so the comparison is consistent even if
+// the head moves during the check.
+func (tracker *ReorgTracker) Check(ctx context.Context) error {
+	head, err := tracker.caller.headerByNumber(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	return tracker.checkFrom(ctx, head)
+}
+
+func (tracker *ReorgTracker) checkFrom(ctx context.Context, head *blockHeader) error {
+	tracker.mu.Lock()
+	if head.Number.Uint64() > tracker.head {
+		tracker.head = head.Number.Uint64()
+	}
+	tracker.pruneLocked()
+
+	// Heights above the head are left for a later head: the head may just be stale, e.g. a poll that lags
+	// behind an execution at the latest block, and a shorter chain grows past them again anyway
+	numbers := make([]uint64, 0, len(tracker.blocks))
+	for number := range tracker.blocks {
+		if number <= head.Number.Uint64() {
+			numbers = append(numbers, number)
+		}
+	}
+	tracker.mu.Unlock()
+
+	if len(numbers) == 0 {
+		return nil
+	}
+	sort.Slice(numbers, func(i, j int) bool { return numbers[i] > numbers[j] })
+
+	// Canonical hashes of the tracked heights, walking parent links from the head
+	canonical := make(map[uint64]common.Hash)
+	header := head
+	for _, number := range numbers {
+		for header.Number.Uint64() > number {
+			if header.Number.Uint64() == number+1 {
+				canonical[number] = header.ParentHash
+			}
+			parent, err := tracker.caller.headerByHash(ctx, header.ParentHash)
+			if err != nil {
+				return err
+			}
+			header = parent
+		}
+		if header.Number.Uint64() == number {
+			canonical[number] = header.Hash()
+		}
+	}
+
+	var retractions []Retraction
+	tracker.mu.Lock()
+	for _, number := range numbers {
+		retractions = append(retractions, tracker.retractLocked(number, canonical[number])...)
+	}
+	tracker.mu.Unlock()
+
+	tracker.emit(retractions)
+	return nil
+}
+
+// Run checks the tracked blocks at every new head until ctx is done
+func (tracker *ReorgTracker) Run(ctx context.Context) {
+	heads := make(chan *blockHeader, 1)
+	go tracker.caller.followHeads(ctx, heads, WatchOptions{SkipBlocks: true, PollInterval: DefaultPollInterval})
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case head := <-heads:
+			// A failed check is simply retried at the next head
+			_ = tracker.checkFrom(ctx, head)
+		}
+	}
+}
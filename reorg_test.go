@@ -0,0 +1,90 @@
+package go_eth_multicall
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestReorgCheckFromStaleHead(t *testing.T) {
+	tracker := NewReorgTracker(&EthMultiCaller{}, 0)
+	head := &blockHeader{Header: &types.Header{Number: big.NewInt(10)}, hash: common.HexToHash("0x1a")}
+
+	tracker.Track(ResultSet{BlockNumber: 10, BlockHash: head.Hash()})
+	tracker.Track(ResultSet{BlockNumber: 10, BlockHash: common.HexToHash("0x0a")})
+	// Read at the latest block before the head below was polled
+	tracker.Track(ResultSet{BlockNumber: 11, BlockHash: common.HexToHash("0x0b"), ParentHash: head.Hash()})
+
+	// The sibling at 10 was already retracted by the parent hash of 11
+	retraction := <-tracker.Retractions()
+	if retraction.BlockNumber != 10 || retraction.BlockHash != common.HexToHash("0x0a") {
+		t.Fatalf("got retraction of %d %s", retraction.BlockNumber, retraction.BlockHash.Hex())
+	}
+
+	if err := tracker.checkFrom(context.Background(), head); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case retraction := <-tracker.Retractions():
+		t.Fatalf("got retraction of %d %s above or at a stale head", retraction.BlockNumber, retraction.BlockHash.Hex())
+	default:
+	}
+	if len(tracker.blocks[11]) != 1 || len(tracker.blocks[10]) != 1 {
+		t.Fatalf("tracked blocks changed: %v", tracker.blocks)
+	}
+}
+
+func TestReorgCheckFromReplacedBlock(t *testing.T) {
+	tracker := NewReorgTracker(&EthMultiCaller{}, 0)
+	head := &blockHeader{Header: &types.Header{Number: big.NewInt(10)}, hash: common.HexToHash("0x1a")}
+	orphan := common.HexToHash("0x0a")
+
+	tracker.Track(ResultSet{BlockNumber: 10, BlockHash: orphan})
+	if err := tracker.checkFrom(context.Background(), head); err != nil {
+		t.Fatal(err)
+	}
+
+	retraction := <-tracker.Retractions()
+	if retraction.BlockHash != orphan || retraction.CanonicalHash != head.Hash() {
+		t.Fatalf("got retraction of %s for %s", retraction.BlockHash.Hex(), retraction.CanonicalHash.Hex())
+	}
+}
+
+func TestReorgCheckReportedHashes(t *testing.T) {
+	node, caller := newFakeNode(t)
+	servePragueHead(t, node)
+	tracker := NewReorgTracker(caller, 0)
+
+	if _, err := tracker.Execute(context.Background(), consistencyCalls(), nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := tracker.Check(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case retraction := <-tracker.Retractions():
+		t.Fatalf("got retraction of canonical block %d %s", retraction.BlockNumber, retraction.BlockHash.Hex())
+	default:
+	}
+}
+
+func TestReorgTrackDropsOldestRetraction(t *testing.T) {
+	tracker := NewReorgTracker(&EthMultiCaller{}, 0)
+	capacity := cap(tracker.retractions)
+
+	// Every odd block orphans the even block tracked below it, more of them than the channel holds
+	for i := uint64(1); i <= uint64(capacity)+2; i++ {
+		tracker.Track(ResultSet{BlockNumber: 2 * i, BlockHash: common.HexToHash("0x0a")})
+		tracker.Track(ResultSet{BlockNumber: 2*i + 1, BlockHash: common.HexToHash("0x0b"), ParentHash: common.HexToHash("0x0c")})
+	}
+
+	if got := len(tracker.retractions); got != capacity {
+		t.Fatalf("got %d retractions queued, want %d", got, capacity)
+	}
+	if oldest := <-tracker.Retractions(); oldest.BlockNumber != 6 {
+		t.Fatalf("got oldest retraction of %d, want the ones of 2 and 4 dropped", oldest.BlockNumber)
+	}
+}
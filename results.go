@@ -0,0 +1,53 @@
+package go_eth_multicall
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ResultSet holds the responses to a set of calls together with the block they were read at.
+// Responses[i] is the response to Calls[i].
+type ResultSet struct {
+	BlockNumber    uint64
+	BlockHash      common.Hash
+	ParentHash     common.Hash
+	BlockTimestamp time.Time
+	Calls          []Call
+	Responses      []CallResponse
+	// Retries is the number of requests to the node that were retried to produce the responses
+	Retries int
+	// Assurance tells how sure it is that all the responses come from the block above
+	Assurance Assurance
+}
+
+// newResultSet returns an empty ResultSet for the calls at the given block
+func newResultSet(header *blockHeader, calls []Call) ResultSet {
+	return ResultSet{
+		BlockNumber:    header.Number.Uint64(),
+		BlockHash:      header.Hash(),
+		ParentHash:     header.ParentHash,
+		BlockTimestamp: time.Unix(int64(header.Time), 0),
+		Calls:          calls,
+	}
+}
+
+// Map returns the responses keyed by call name, like Execute does
+func (set ResultSet) Map() map[string]CallResponse {
+	results := make(map[string]CallResponse, len(set.Calls))
+	for i, call := range set.answered() {
+		results[call.Name] = set.Responses[i]
+	}
+
+	return results
+}
+
+// answered returns the calls that have a response. A result set built by hand, or cut short, may hold fewer
+// responses than calls, the calls past the last response are left out.
+func (set ResultSet) answered() []Call {
+	if len(set.Responses) < len(set.Calls) {
+		return set.Calls[:len(set.Responses)]
+	}
+
+	return set.Calls
+}
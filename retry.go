@@ -0,0 +1,274 @@
+package go_eth_multicall
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/big"
+	"math/rand"
+	"net"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// ErrorKind is the class of an error returned by a node, telling whether retrying may help
+type ErrorKind int
+
+const (
+	// ErrorUnknown is an error that is not recognized, it is not retried
+	ErrorUnknown ErrorKind = iota
+	// ErrorRateLimited is a 429 or a provider specific rate or compute unit limit
+	ErrorRateLimited
+	// ErrorTimeout is a request that timed out on the way to or from the node
+	ErrorTimeout
+	// ErrorConnection is a refused, reset or dropped connection
+	ErrorConnection
+	// ErrorUnavailable is a node or gateway answering with a 5xx status
+	ErrorUnavailable
+	// ErrorReverted is a call that reverted, it fails the same way every time
+	ErrorReverted
+	// ErrorInvalidParams is a request the node rejects as malformed
+	ErrorInvalidParams
+	// ErrorStatePruned is a read at a block whose state the node no longer has
+	ErrorStatePruned
+)
+
+func (kind ErrorKind) String() string {
+	switch kind {
+	case ErrorRateLimited:
+		return "rate limited"
+	case ErrorTimeout:
+		return "timeout"
+	case ErrorConnection:
+		return "connection"
+	case ErrorUnavailable:
+		return "unavailable"
+	case ErrorReverted:
+		return "reverted"
+	case ErrorInvalidParams:
+		return "invalid params"
+	case ErrorStatePruned:
+		return "state pruned"
+	}
+
+	return "unknown"
+}
+
+// Retryable reports whether an error of this kind may go away on its own
+func (kind ErrorKind) Retryable() bool {
+	switch kind {
+	case ErrorRateLimited, ErrorTimeout, ErrorConnection, ErrorUnavailable:
+		return true
+	}
+
+	return false
+}
+
+// ClassifyError tells what kind of failure an error returned by the node or the transport is
+func ClassifyError(err error) ErrorKind {
+	if err == nil {
+		return ErrorUnknown
+	}
+
+	if errors.Is(err, ErrRateLimited) {
+		return ErrorRateLimited
+	}
+
+	var httpErr rpc.HTTPError
+	if errors.As(err, &httpErr) {
+		switch {
+		case httpErr.StatusCode == 429:
+			return ErrorRateLimited
+		case httpErr.StatusCode == 408:
+			return ErrorTimeout
+		case httpErr.StatusCode >= 500:
+			return ErrorUnavailable
+		}
+	}
+
+	// The error code comes first: rate limits carry data too, e.g. Infura's -32005 tells how long to back off.
+	// Data is only revert data along with code 3 or an execution reverted message, both handled below.
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) {
+		switch rpcErr.ErrorCode() {
+		case 3:
+			return ErrorReverted
+		case -32602:
+			return ErrorInvalidParams
+		case -32005:
+			// Used by most providers for rate and compute unit limits
+			return ErrorRateLimited
+		}
+	}
+
+	message := strings.ToLower(err.Error())
+	switch {
+	case strings.Contains(message, "execution reverted"), strings.Contains(message, "revert"):
+		return ErrorReverted
+	case strings.Contains(message, "missing trie node"), strings.Contains(message, "pruned"),
+		strings.Contains(message, "state not available"), strings.Contains(message, "state histories"):
+		return ErrorStatePruned
+	case strings.Contains(message, "invalid argument"), strings.Contains(message, "invalid params"):
+		return ErrorInvalidParams
+	case strings.Contains(message, "too many requests"), strings.Contains(message, "rate limit"),
+		strings.Contains(message, "limit exceeded"), strings.Contains(message, "capacity exceeded"):
+		return ErrorRateLimited
+	}
+
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, syscall.ETIMEDOUT) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return ErrorTimeout
+	}
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.EPIPE) ||
+		errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) || strings.Contains(message, "connection reset") {
+		return ErrorConnection
+	}
+
+	return ErrorUnknown
+}
+
+// RetryPolicy describes how the calls to the node are retried after a retryable error. The delay before the n-th
+// retry is InitialInterval * Multiplier^(n-1), capped at MaxInterval and spread by ±Jitter of itself.
+type RetryPolicy struct {
+	InitialInterval time.Duration
+	MaxInterval     time.Duration
+	Multiplier      float64
+	// Jitter is the fraction of the delay it is randomized by, between 0 and 1
+	Jitter float64
+	// MaxElapsedTime stops retrying once the next attempt would start that long after the first one. Zero means
+	// no limit.
+	MaxElapsedTime time.Duration
+	// MaxAttempts caps the attempts, the first one included. Zero means no limit.
+	MaxAttempts int
+	// Retryable decides which errors are retried, the ones whose ClassifyError kind is Retryable when nil
+	Retryable func(err error) bool
+}
+
+// DefaultRetryPolicy returns the policy New sets: up to 30 seconds of retries starting at 250ms
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		InitialInterval: 250 * time.Millisecond,
+		MaxInterval:     5 * time.Second,
+		Multiplier:      2,
+		Jitter:          0.5,
+		MaxElapsedTime:  30 * time.Second,
+	}
+}
+
+func (policy *RetryPolicy) retryable(err error) bool {
+	if policy.Retryable != nil {
+		return policy.Retryable(err)
+	}
+
+	return ClassifyError(err).Retryable()
+}
+
+// delay returns the randomized delay for an interval
+func (policy *RetryPolicy) delay(interval time.Duration) time.Duration {
+	if policy.Jitter <= 0 {
+		return interval
+	}
+
+	spread := policy.Jitter * float64(interval)
+	return time.Duration(float64(interval) - spread + rand.Float64()*2*spread)
+}
+
+type retryCounterKey struct{}
+
+// countRetries returns a context whose retries are counted in the returned counter
+func countRetries(ctx context.Context) (context.Context, *int64) {
+	counter := new(int64)
+	return context.WithValue(ctx, retryCounterKey{}, counter), counter
+}
+
+// addRetry adds a retry to the counter of the context, if any
+func addRetry(ctx context.Context) {
+	if counter, ok := ctx.Value(retryCounterKey{}).(*int64); ok {
+		atomic.AddInt64(counter, 1)
+	}
+}
+
+// withRetry runs do until it succeeds, fails with an error that is not retryable, or the policy gives up.
+// The retries are added to the counter of the context, if any. Requests routed to an endpoint of a pool are not
+// retried here, the pool fails over to another endpoint and retries as a whole.
+func (caller *EthMultiCaller) withRetry(ctx context.Context, do func() error) error {
+	err := do()
+	policy := caller.Retry
+	if err == nil || policy == nil || endpointFrom(ctx) != nil {
+		return err
+	}
+	if errors.Is(err, ErrRateLimited) {
+		// A fail-fast limiter is meant to fail right away
+		return err
+	}
+
+	start := time.Now()
+	interval := policy.InitialInterval
+	for attempt := 1; ; attempt++ {
+		if ctx.Err() != nil || !policy.retryable(err) {
+			return err
+		}
+		if policy.MaxAttempts > 0 && attempt >= policy.MaxAttempts {
+			return err
+		}
+
+		delay := policy.delay(interval)
+		if policy.MaxElapsedTime > 0 && time.Since(start)+delay > policy.MaxElapsedTime {
+			return err
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		}
+
+		addRetry(ctx)
+		if err = do(); err == nil {
+			return nil
+		}
+
+		if policy.Multiplier > 0 {
+			interval = time.Duration(float64(interval) * policy.Multiplier)
+		}
+		if policy.MaxInterval > 0 && interval > policy.MaxInterval {
+			interval = policy.MaxInterval
+		}
+	}
+}
+
+// callContract is Client.CallContract under the retry policy
+func (caller *EthMultiCaller) callContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	var resp []byte
+	err := caller.withRetry(ctx, func() (err error) {
+		if err := caller.limiter(ctx).Wait(ctx, "eth_call", 1); err != nil {
+			return err
+		}
+		resp, err = caller.client(ctx).CallContract(ctx, msg, blockNumber)
+		return err
+	})
+
+	return resp, err
+}
+
+// callContractAtHash is Client.CallContractAtHash under the retry policy
+func (caller *EthMultiCaller) callContractAtHash(ctx context.Context, msg ethereum.CallMsg, blockHash common.Hash) ([]byte, error) {
+	var resp []byte
+	err := caller.withRetry(ctx, func() (err error) {
+		if err := caller.limiter(ctx).Wait(ctx, "eth_call", 1); err != nil {
+			return err
+		}
+		resp, err = caller.client(ctx).CallContractAtHash(ctx, msg, blockHash)
+		return err
+	})
+
+	return resp, err
+}
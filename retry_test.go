@@ -0,0 +1,127 @@
+package go_eth_multicall
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// nodeError returns the error a JSON-RPC client gets from a node answering with the given status and body
+func nodeError(t *testing.T, status int, body string) error {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		io.WriteString(w, body)
+	}))
+	defer server.Close()
+
+	client, err := rpc.DialHTTP(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	var result interface{}
+	err = client.CallContext(context.Background(), &result, "eth_call")
+	if err == nil {
+		t.Fatal("the node did not fail")
+	}
+
+	return err
+}
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		want   ErrorKind
+	}{
+		{
+			name:   "infura rate limit with data",
+			status: http.StatusOK,
+			body:   `{"jsonrpc":"2.0","id":1,"error":{"code":-32005,"message":"daily request count exceeded, request rate limited","data":{"see":"https://infura.io/dashboard","current_rps":13.333,"allowed_rps":10.0,"backoff_seconds":30.0}}}`,
+			want:   ErrorRateLimited,
+		},
+		{
+			name:   "revert with reason",
+			status: http.StatusOK,
+			body:   `{"jsonrpc":"2.0","id":1,"error":{"code":3,"message":"execution reverted: Ownable: caller is not the owner","data":"0x08c379a0000000000000000000000000000000000000000000000000000000000000002000000000000000000000000000000000000000000000000000000000000000204f776e61626c653a2063616c6c6572206973206e6f7420746865206f776e6572"}}`,
+			want:   ErrorReverted,
+		},
+		{
+			name:   "revert without data",
+			status: http.StatusOK,
+			body:   `{"jsonrpc":"2.0","id":1,"error":{"code":-32000,"message":"execution reverted"}}`,
+			want:   ErrorReverted,
+		},
+		{
+			name:   "invalid params",
+			status: http.StatusOK,
+			body:   `{"jsonrpc":"2.0","id":1,"error":{"code":-32602,"message":"invalid argument 0: hex string has length 3, want 40 for common.Address"}}`,
+			want:   ErrorInvalidParams,
+		},
+		{
+			name:   "pruned state",
+			status: http.StatusOK,
+			body:   `{"jsonrpc":"2.0","id":1,"error":{"code":-32000,"message":"missing trie node 1b6a2a0f31a4dd3a7f1f9d9d1b3f0c6b2a9c8e7d6f5a4b3c2d1e0f9a8b7c6d5e (path )"}}`,
+			want:   ErrorStatePruned,
+		},
+		{
+			name:   "unknown node error with data",
+			status: http.StatusOK,
+			body:   `{"jsonrpc":"2.0","id":1,"error":{"code":-32000,"message":"header not found","data":{"block":"0x1"}}}`,
+			want:   ErrorUnknown,
+		},
+		{
+			name:   "http 429",
+			status: http.StatusTooManyRequests,
+			body:   `{"jsonrpc":"2.0","id":1,"error":{"code":429,"message":"Your app has exceeded its compute units per second capacity"}}`,
+			want:   ErrorRateLimited,
+		},
+		{
+			name:   "http 503",
+			status: http.StatusServiceUnavailable,
+			body:   `service unavailable`,
+			want:   ErrorUnavailable,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := nodeError(t, test.status, test.body)
+			if got := ClassifyError(err); got != test.want {
+				t.Fatalf("%v: got %s, want %s", err, got, test.want)
+			}
+			// Wrapping keeps the classification
+			if got := ClassifyError(fmt.Errorf("multicall: %w", err)); got != test.want {
+				t.Fatalf("wrapped %v: got %s, want %s", err, got, test.want)
+			}
+		})
+	}
+}
+
+func TestClassifyErrorTransport(t *testing.T) {
+	tests := []struct {
+		err  error
+		want ErrorKind
+	}{
+		{err: nil, want: ErrorUnknown},
+		{err: ErrRateLimited, want: ErrorRateLimited},
+		{err: context.DeadlineExceeded, want: ErrorTimeout},
+		{err: io.ErrUnexpectedEOF, want: ErrorConnection},
+		{err: errors.New("read tcp 10.0.0.1:443: connection reset by peer"), want: ErrorConnection},
+	}
+
+	for _, test := range tests {
+		if got := ClassifyError(test.err); got != test.want {
+			t.Errorf("%v: got %s, want %s", test.err, got, test.want)
+		}
+	}
+}
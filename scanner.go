@@ -0,0 +1,541 @@
+package go_eth_multicall
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	DefaultScanConcurrency = 4
+	DefaultScanBatchSize   = 20
+)
+
+// ScanOptions describes the blocks a scan runs at and how hard it may push the node
+type ScanOptions struct {
+	FromBlock uint64
+	ToBlock   uint64
+	// Step runs the calls at every Step-th block starting at FromBlock, 1 when zero
+	Step uint64
+	// Concurrency is the number of requests in flight, DefaultScanConcurrency when zero
+	Concurrency int
+	// RequestsPerSecond caps the rate of requests sent to the node, a JSON-RPC batch counting as one request.
+	// Zero means no limit.
+	RequestsPerSecond float64
+	// BatchSize is the number of blocks sent in a single JSON-RPC batch, DefaultScanBatchSize when zero.
+	// One disables batching, which also happens automatically when the node rejects batches.
+	BatchSize int
+	// CheckpointPath is a file recording every finished block. A scan started with the checkpoint of an
+	// interrupted scan of the same calls and blocks only runs the blocks that are missing.
+	CheckpointPath string
+}
+
+// ScanPoint is the responses to the calls at one block
+type ScanPoint struct {
+	BlockNumber uint64         `json:"blockNumber"`
+	Responses   []CallResponse `json:"responses"`
+}
+
+// ScanError is the failure of the calls at one block of a scan
+type ScanError struct {
+	BlockNumber uint64
+	Err         error
+}
+
+func (err *ScanError) Error() string {
+	return fmt.Sprintf("multicall: scan block %d: %v", err.BlockNumber, err.Err)
+}
+
+func (err *ScanError) Unwrap() error {
+	return err.Err
+}
+
+// Sample is the response of one call at one block
+type Sample struct {
+	BlockNumber uint64
+	Response    CallResponse
+}
+
+// TimeSeries holds the samples of each call by call name, in block order
+type TimeSeries map[string][]Sample
+
+// Scan executes the calls at every Step-th block between FromBlock and ToBlock (inclusive) and returns the
+// responses of each call as a time series. Historical blocks require an archive node.
+func (caller *EthMultiCaller) Scan(ctx context.Context, calls []Call, options ScanOptions) (TimeSeries, error) {
+	if options.Step == 0 {
+		options.Step = 1
+	}
+	if options.Concurrency <= 0 {
+		options.Concurrency = DefaultScanConcurrency
+	}
+	if options.BatchSize <= 0 {
+		options.BatchSize = DefaultScanBatchSize
+	}
+	if options.ToBlock < options.FromBlock {
+		return nil, errors.New("multicall: scan ends before it starts")
+	}
+	if _, err := caller.multicallAt(new(big.Int).SetUint64(options.FromBlock)); err != nil {
+		return nil, err
+	}
+
+	scanner := &scanner{caller: caller, calls: calls, options: options, batches: caller.rpcClient(ctx) != nil && options.BatchSize > 1}
+	scanner.unique, scanner.indexes = deduplicateCalls(calls)
+
+	// Batches hold one tryAggregate per chunk and block, packed for each multicall contract the blocks may run through
+	multicalls := []common.Address{caller.ContractAddress}
+	if caller.Chain != nil && caller.Variant != "" {
+		for _, deployment := range caller.Chain.Multicalls {
+			multicalls = append(multicalls, deployment.Address)
+		}
+	}
+	scanner.callData = make(map[common.Address][][]byte, len(multicalls))
+	scanner.chunks = chunkCalls(scanner.unique, caller.ChunkSize)
+	for _, multicall := range multicalls {
+		if _, ok := scanner.callData[multicall]; ok {
+			continue
+		}
+		for _, chunk := range scanner.chunks {
+			callData, err := caller.packTryAggregate(caller.retarget(chunk, multicall))
+			if err != nil {
+				return nil, err
+			}
+			scanner.callData[multicall] = append(scanner.callData[multicall], callData)
+		}
+	}
+
+	done := make(map[uint64][]CallResponse)
+	if options.CheckpointPath != "" {
+		if err := scanner.openCheckpoint(ctx, done); err != nil {
+			return nil, err
+		}
+		defer scanner.checkpoint.Close()
+	}
+
+	var pending []uint64
+	for block := options.FromBlock; block <= options.ToBlock; block += options.Step {
+		if _, ok := done[block]; !ok {
+			pending = append(pending, block)
+		}
+		if block+options.Step < block {
+			break
+		}
+	}
+
+	if err := scanner.run(ctx, pending, done); err != nil {
+		return nil, err
+	}
+
+	blocks := make([]uint64, 0, len(done))
+	for block := range done {
+		blocks = append(blocks, block)
+	}
+	sort.Slice(blocks, func(i, j int) bool { return blocks[i] < blocks[j] })
+
+	series := make(TimeSeries, len(calls))
+	for _, block := range blocks {
+		for i, call := range calls {
+			series[call.Name] = append(series[call.Name], Sample{BlockNumber: block, Response: done[block][i]})
+		}
+	}
+
+	return series, nil
+}
+
+type scanner struct {
+	caller   *EthMultiCaller
+	calls    []Call
+	unique   []Call
+	indexes  []int
+	chunks   [][]Call
+	callData map[common.Address][][]byte
+	options  ScanOptions
+
+	mu         sync.Mutex
+	batches    bool
+	checkpoint *os.File
+}
+
+type checkpointHeader struct {
+	Fingerprint common.Hash `json:"fingerprint"`
+}
+
+// fingerprint identifies the chain, calls and blocks of a scan, so a checkpoint is never resumed by a different
+// scan, or by the same scan on another chain
+func (scanner *scanner) fingerprint(chainID uint64) common.Hash {
+	data := []byte(fmt.Sprintf("%d:%s:%d:%d:%d:", chainID, scanner.caller.ContractAddress.Hex(), scanner.options.FromBlock, scanner.options.ToBlock, scanner.options.Step))
+	for _, call := range scanner.calls {
+		data = append(data, call.Target.Bytes()...)
+		data = append(data, crypto.Keccak256(call.CallData)...)
+	}
+
+	return crypto.Keccak256Hash(data)
+}
+
+// openCheckpoint loads the finished blocks of the checkpoint into done and opens it for appending
+func (scanner *scanner) openCheckpoint(ctx context.Context, done map[uint64][]CallResponse) error {
+	chainID, err := scanner.caller.chainIDUint64(ctx)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(scanner.options.CheckpointPath, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return err
+	}
+
+	fingerprint := scanner.fingerprint(chainID)
+	reader := bufio.NewReader(file)
+	line, err := reader.ReadBytes('\n')
+	if len(line) == 0 && err != nil {
+		// New checkpoint
+		header, _ := json.Marshal(checkpointHeader{Fingerprint: fingerprint})
+		if _, err := file.Write(append(header, '\n')); err != nil {
+			file.Close()
+			return err
+		}
+		scanner.checkpoint = file
+		return nil
+	}
+
+	var header checkpointHeader
+	if err := json.Unmarshal(line, &header); err != nil || header.Fingerprint != fingerprint {
+		file.Close()
+		return fmt.Errorf("multicall: checkpoint %s belongs to another scan", scanner.options.CheckpointPath)
+	}
+
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err != nil {
+			// A partially written last line is dropped and its block scanned again
+			break
+		}
+
+		var point ScanPoint
+		if json.Unmarshal(line, &point) == nil && len(point.Responses) == len(scanner.calls) {
+			done[point.BlockNumber] = point.Responses
+		}
+	}
+
+	// Rewrite the checkpoint without the partial line before appending to it
+	if err := file.Truncate(0); err != nil {
+		file.Close()
+		return err
+	}
+	if _, err := file.Seek(0, 0); err != nil {
+		file.Close()
+		return err
+	}
+	writer := bufio.NewWriter(file)
+	headerLine, _ := json.Marshal(header)
+	writer.Write(append(headerLine, '\n'))
+	for block, responses := range done {
+		pointLine, _ := json.Marshal(ScanPoint{BlockNumber: block, Responses: responses})
+		writer.Write(append(pointLine, '\n'))
+	}
+	if err := writer.Flush(); err != nil {
+		file.Close()
+		return err
+	}
+
+	scanner.checkpoint = file
+	return nil
+}
+
+func (scanner *scanner) record(point ScanPoint, done map[uint64][]CallResponse) error {
+	scanner.mu.Lock()
+	defer scanner.mu.Unlock()
+
+	done[point.BlockNumber] = point.Responses
+	if scanner.checkpoint == nil {
+		return nil
+	}
+
+	line, err := json.Marshal(point)
+	if err != nil {
+		return err
+	}
+	_, err = scanner.checkpoint.Write(append(line, '\n'))
+	return err
+}
+
+// run scans the pending blocks with the configured concurrency, recording each finished block
+func (scanner *scanner) run(ctx context.Context, pending []uint64, done map[uint64][]CallResponse) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var throttle <-chan time.Time
+	if scanner.options.RequestsPerSecond > 0 {
+		ticker := time.NewTicker(time.Duration(float64(time.Second) / scanner.options.RequestsPerSecond))
+		defer ticker.Stop()
+		throttle = ticker.C
+	}
+
+	groups := make(chan []uint64)
+	go func() {
+		defer close(groups)
+
+		for start := 0; start < len(pending); start += scanner.options.BatchSize {
+			end := start + scanner.options.BatchSize
+			if end > len(pending) {
+				end = len(pending)
+			}
+
+			select {
+			case groups <- pending[start:end]:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	var firstErr error
+	var errOnce sync.Once
+	fail := func(err error) {
+		errOnce.Do(func() {
+			firstErr = err
+			cancel()
+		})
+	}
+
+	for worker := 0; worker < scanner.options.Concurrency; worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for group := range groups {
+				points, err := scanner.executeGroup(ctx, group, throttle)
+				if err != nil {
+					fail(err)
+					return
+				}
+				for _, point := range points {
+					if err := scanner.record(point, done); err != nil {
+						fail(err)
+						return
+					}
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+	if firstErr != nil {
+		return firstErr
+	}
+
+	return ctx.Err()
+}
+
+func wait(ctx context.Context, throttle <-chan time.Time) error {
+	if throttle == nil {
+		return ctx.Err()
+	}
+
+	select {
+	case <-throttle:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// executeGroup runs the calls at each block of the group, in a single JSON-RPC batch when possible
+func (scanner *scanner) executeGroup(ctx context.Context, blocks []uint64, throttle <-chan time.Time) ([]ScanPoint, error) {
+	var points []ScanPoint
+	err := scanner.caller.route(ctx, func(ctx context.Context) (err error) {
+		points, err = scanner.executeGroupOnce(ctx, blocks, throttle)
+		return err
+	})
+
+	return points, err
+}
+
+func (scanner *scanner) executeGroupOnce(ctx context.Context, blocks []uint64, throttle <-chan time.Time) ([]ScanPoint, error) {
+	scanner.mu.Lock()
+	batches := scanner.batches
+	scanner.mu.Unlock()
+
+	// The store is consulted block by block, so scans served from it skip batching, and the batch strategy
+	// already batches the calls of each block
+	if scanner.caller.Store != nil || scanner.caller.Strategy == StrategyBatch {
+		return scanner.executeEach(ctx, blocks, throttle)
+	}
+
+	if batches && len(blocks) > 1 {
+		points, err := scanner.executeBatch(ctx, blocks, throttle)
+		var blockErr *ScanError
+		if err == nil {
+			return points, nil
+		}
+		if ctx.Err() != nil || errors.As(err, &blockErr) {
+			return nil, err
+		}
+
+		// The node does not take batches (or not batches this large), stop trying
+		scanner.mu.Lock()
+		scanner.batches = false
+		scanner.mu.Unlock()
+	}
+
+	points := make([]ScanPoint, 0, len(blocks))
+	for _, block := range blocks {
+		if err := wait(ctx, throttle); err != nil {
+			return nil, err
+		}
+
+		// tryAggregate chunks, bisects poisoned aggregates and traces failures
+		uniqueResponses, err := scanner.caller.tryAggregate(ctx, scanner.unique, new(big.Int).SetUint64(block))
+		if err != nil {
+			return nil, &ScanError{BlockNumber: block, Err: err}
+		}
+
+		point, err := scanner.point(block, uniqueResponses)
+		if err != nil {
+			return nil, err
+		}
+		points = append(points, point)
+	}
+
+	return points, nil
+}
+
+func (scanner *scanner) executeEach(ctx context.Context, blocks []uint64, throttle <-chan time.Time) ([]ScanPoint, error) {
+	points := make([]ScanPoint, 0, len(blocks))
+	for _, block := range blocks {
+		if err := wait(ctx, throttle); err != nil {
+			return nil, err
+		}
+
+		responses, err := scanner.caller.ExecuteContext(ctx, scanner.calls, new(big.Int).SetUint64(block))
+		if err != nil {
+			return nil, &ScanError{BlockNumber: block, Err: err}
+		}
+		points = append(points, ScanPoint{BlockNumber: block, Responses: responses})
+	}
+
+	return points, nil
+}
+
+func (scanner *scanner) executeBatch(ctx context.Context, blocks []uint64, throttle <-chan time.Time) ([]ScanPoint, error) {
+	if err := wait(ctx, throttle); err != nil {
+		return nil, err
+	}
+
+	chunks := len(scanner.chunks)
+	results := make([]hexutil.Bytes, len(blocks)*chunks)
+	batch := make([]rpc.BatchElem, len(blocks)*chunks)
+	for i, block := range blocks {
+		multicall, err := scanner.caller.multicallAt(new(big.Int).SetUint64(block))
+		if err != nil {
+			return nil, &ScanError{BlockNumber: block, Err: err}
+		}
+		for j, callData := range scanner.callData[multicall] {
+			batch[i*chunks+j] = rpc.BatchElem{
+				Method: "eth_call",
+				Args: []interface{}{
+					map[string]interface{}{"to": multicall, "data": hexutil.Bytes(callData)},
+					hexutil.EncodeUint64(block),
+				},
+				Result: &results[i*chunks+j],
+			}
+		}
+	}
+
+	err := scanner.caller.withRetry(ctx, func() error {
+		if err := scanner.caller.limiter(ctx).Wait(ctx, "eth_call", len(batch)); err != nil {
+			return err
+		}
+		for i := range batch {
+			batch[i].Error = nil
+		}
+		return scanner.caller.rpcClient(ctx).BatchCallContext(ctx, batch)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	points := make([]ScanPoint, 0, len(blocks))
+	for i, block := range blocks {
+		uniqueResponses, err := scanner.batchResponses(ctx, block, batch[i*chunks:(i+1)*chunks], results[i*chunks:(i+1)*chunks])
+		if err != nil {
+			return nil, err
+		}
+
+		point, err := scanner.point(block, uniqueResponses)
+		if err != nil {
+			return nil, err
+		}
+		points = append(points, point)
+	}
+
+	return points, nil
+}
+
+// batchResponses unpacks the aggregates of the chunks at one block. Poisoned aggregates are bisected, chunks that
+// failed otherwise are run again outside the batch, and the failures are traced like tryAggregate does.
+func (scanner *scanner) batchResponses(ctx context.Context, block uint64, batch []rpc.BatchElem, results []hexutil.Bytes) ([]CallResponse, error) {
+	blockNumber := new(big.Int).SetUint64(block)
+
+	responses := make([]CallResponse, 0, len(scanner.unique))
+	for j, chunk := range scanner.chunks {
+		err := batch[j].Error
+		var chunkResponses []CallResponse
+		if err == nil {
+			chunkResponses, err = scanner.caller.unpackTryAggregate(results[j])
+		}
+		switch {
+		case err == nil:
+		case poisoned(ctx, err):
+			chunkResponses, err = scanner.caller.bisectAggregate(ctx, chunk, err, func(calls []Call) ([]CallResponse, error) {
+				return scanner.caller.aggregate(ctx, calls, blockNumber)
+			})
+		default:
+			// The chunk failed within the batch, e.g. rate limited or refused as part of a batch. Run it on its own
+			// under the retry policy, and stop batching when the node only refused it batched.
+			refused := !ClassifyError(err).Retryable()
+			chunkResponses, err = scanner.caller.tryAggregateChunk(ctx, chunk, blockNumber)
+			if err == nil && refused {
+				scanner.mu.Lock()
+				scanner.batches = false
+				scanner.mu.Unlock()
+			}
+		}
+		if err != nil {
+			return nil, &ScanError{BlockNumber: block, Err: err}
+		}
+		if len(chunkResponses) != len(chunk) {
+			return nil, &ScanError{BlockNumber: block, Err: fmt.Errorf("got %d responses for %d calls", len(chunkResponses), len(chunk))}
+		}
+		responses = append(responses, chunkResponses...)
+	}
+	scanner.caller.traceFailures(ctx, scanner.unique, responses, blockArg(blockNumber, nil))
+
+	return responses, nil
+}
+
+// point maps the responses of the distinct calls back to every call
+func (scanner *scanner) point(block uint64, uniqueResponses []CallResponse) (ScanPoint, error) {
+	if len(uniqueResponses) != len(scanner.unique) {
+		return ScanPoint{}, &ScanError{BlockNumber: block, Err: fmt.Errorf("got %d responses for %d calls", len(uniqueResponses), len(scanner.unique))}
+	}
+
+	point := ScanPoint{BlockNumber: block, Responses: make([]CallResponse, len(scanner.calls))}
+	for i := range scanner.calls {
+		point.Responses[i] = uniqueResponses[scanner.indexes[i]]
+	}
+
+	return point, nil
+}
@@ -0,0 +1,117 @@
+package go_eth_multicall
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	MultiCall2 "github.com/truongpx396/go-eth-multicall/contracts/MultiCall"
+)
+
+func TestScanFingerprint(t *testing.T) {
+	calls := []Call{{Name: "a", Target: common.HexToAddress("0x01"), CallData: []byte{1, 2, 3, 4}}}
+	scan := &scanner{caller: &EthMultiCaller{}, calls: calls, options: ScanOptions{FromBlock: 10, ToBlock: 20, Step: 1}}
+
+	if scan.fingerprint(1) != scan.fingerprint(1) {
+		t.Fatal("fingerprint is not stable")
+	}
+	if scan.fingerprint(1) == scan.fingerprint(137) {
+		t.Fatal("scans of different chains share a fingerprint")
+	}
+
+	other := &scanner{caller: &EthMultiCaller{}, calls: calls, options: ScanOptions{FromBlock: 10, ToBlock: 21, Step: 1}}
+	if scan.fingerprint(1) == other.fingerprint(1) {
+		t.Fatal("scans of different blocks share a fingerprint")
+	}
+}
+
+func TestScanChunks(t *testing.T) {
+	for _, batchSize := range []int{1, DefaultScanBatchSize} {
+		node, caller := newFakeNode(t)
+		caller.ChunkSize = 2
+
+		calls := bisectCalls(5)
+		// The aggregate holding the third call runs out of gas
+		node.fail = func(attempt int, aggregated []MultiCall2.Multicall2Call) error {
+			for _, call := range aggregated {
+				if bytes.Equal(call.CallData, calls[2].CallData) {
+					return errors.New("out of gas")
+				}
+			}
+			return nil
+		}
+
+		series, err := caller.Scan(context.Background(), calls, ScanOptions{FromBlock: 98, ToBlock: 100, BatchSize: batchSize})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for i, call := range calls {
+			samples := series[call.Name]
+			if len(samples) != 3 {
+				t.Fatalf("batch size %d: got %d samples of %s", batchSize, len(samples), call.Name)
+			}
+			for _, sample := range samples {
+				poisoner := i == 2
+				if sample.Response.Success == poisoner || (!poisoner && !bytes.Equal(sample.Response.ReturnData, call.CallData)) {
+					t.Errorf("batch size %d: %s at %d: got %+v", batchSize, call.Name, sample.BlockNumber, sample.Response)
+				}
+			}
+		}
+
+		// Per block: 3 chunks, then the poisoned chunk split in 2
+		if got := node.count("eth_call"); got != 3*5 {
+			t.Errorf("batch size %d: got %d eth_calls, want %d", batchSize, got, 3*5)
+		}
+	}
+}
+
+func TestScanBatchElementErrors(t *testing.T) {
+	tests := []struct {
+		err         error
+		wantBatches bool
+	}{
+		{err: errors.New("too many requests"), wantBatches: true},
+		{err: errors.New("eth_call is not available in batches"), wantBatches: false},
+	}
+
+	for _, test := range tests {
+		node, caller := newFakeNode(t)
+		calls := bisectCalls(3)
+		scan := &scanner{caller: caller, calls: calls, batches: true, chunks: [][]Call{calls}}
+		scan.unique, scan.indexes = deduplicateCalls(calls)
+		callData, err := caller.packTryAggregate(calls)
+		if err != nil {
+			t.Fatal(err)
+		}
+		scan.callData = map[common.Address][][]byte{caller.ContractAddress: {callData}}
+
+		// Every element of the batch fails, the calls on their own go through
+		node.fail = func(attempt int, aggregated []MultiCall2.Multicall2Call) error {
+			if attempt <= 2 {
+				return test.err
+			}
+			return nil
+		}
+
+		points, err := scan.executeBatch(context.Background(), []uint64{99, 100}, nil)
+		if err != nil {
+			t.Fatalf("%v: %v", test.err, err)
+		}
+		for _, point := range points {
+			for i, response := range point.Responses {
+				if !response.Success || !bytes.Equal(response.ReturnData, calls[i].CallData) {
+					t.Errorf("%v: %s at %d: got %+v", test.err, calls[i].Name, point.BlockNumber, response)
+				}
+			}
+		}
+		if scan.batches != test.wantBatches {
+			t.Errorf("%v: got batches %v, want %v", test.err, scan.batches, test.wantBatches)
+		}
+		if got := node.count("eth_call"); got != 4 {
+			t.Errorf("%v: got %d eth_calls, want the batch of 2 and a call per block", test.err, got)
+		}
+	}
+}
@@ -0,0 +1,341 @@
+package go_eth_multicall
+
+import (
+	"container/list"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// DefaultStoreMaxBytes is the size limit of a FileStore when none is given
+const DefaultStoreMaxBytes = 256 << 20
+
+// StoreKey identifies the response of a call at a block
+type StoreKey struct {
+	ChainID   uint64
+	BlockHash common.Hash
+	Target    common.Address
+	CallData  []byte
+}
+
+func newStoreKey(chainID uint64, blockHash common.Hash, call Call) StoreKey {
+	return StoreKey{ChainID: chainID, BlockHash: blockHash, Target: call.Target, CallData: call.CallData}
+}
+
+// Hash returns a fixed size digest of the key
+func (key StoreKey) Hash() common.Hash {
+	var chainID [8]byte
+	binary.BigEndian.PutUint64(chainID[:], key.ChainID)
+
+	return crypto.Keccak256Hash(chainID[:], key.BlockHash.Bytes(), key.Target.Bytes(), key.CallData)
+}
+
+// ResultStore is a persistent cache of call responses. The caller only stores responses read at finalized blocks,
+// which can never change, so entries never need to be invalidated, only evicted. Responses with an Error are not
+// stored, the error tells why the call could not be executed rather than what it returned.
+//
+// Errors of a store are not fatal: a failing Get is a miss and a failing Put is ignored.
+type ResultStore interface {
+	Get(key StoreKey) (CallResponse, bool, error)
+	Put(key StoreKey, response CallResponse) error
+}
+
+// StoreStats are the counters of a FileStore
+type StoreStats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+	Entries   int
+	Bytes     int64
+}
+
+// FileStoreOptions are the size limits of a FileStore
+type FileStoreOptions struct {
+	// MaxBytes caps the total size of the stored responses, DefaultStoreMaxBytes when zero
+	MaxBytes int64
+	// MaxEntries caps the number of stored responses, zero means no limit
+	MaxEntries int
+}
+
+type storeEntry struct {
+	name string
+	size int64
+}
+
+// FileStore is a ResultStore keeping one file per response in a directory. When a limit is exceeded the least
+// recently used responses are evicted. Use times survive restarts through the modification time of the files.
+//
+// A FileStore is safe for concurrent use, but a directory must not be shared by two open stores.
+type FileStore struct {
+	dir     string
+	options FileStoreOptions
+
+	mu        sync.Mutex
+	entries   map[string]*list.Element
+	recent    *list.List
+	bytes     int64
+	hits      uint64
+	misses    uint64
+	evictions uint64
+}
+
+// OpenFileStore opens the store in dir, creating the directory if needed
+func OpenFileStore(dir string, options FileStoreOptions) (*FileStore, error) {
+	if options.MaxBytes <= 0 {
+		options.MaxBytes = DefaultStoreMaxBytes
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	type storedFile struct {
+		storeEntry
+		used time.Time
+	}
+	var stored []storedFile
+	for _, file := range files {
+		if file.IsDir() {
+			continue
+		}
+		if strings.HasPrefix(file.Name(), ".tmp-") {
+			// Left over by an interrupted Put
+			os.Remove(filepath.Join(dir, file.Name()))
+			continue
+		}
+		info, err := file.Info()
+		if err != nil {
+			continue
+		}
+		stored = append(stored, storedFile{storeEntry{name: file.Name(), size: info.Size()}, info.ModTime()})
+	}
+	sort.Slice(stored, func(i, j int) bool { return stored[i].used.Before(stored[j].used) })
+
+	store := &FileStore{dir: dir, options: options, entries: make(map[string]*list.Element), recent: list.New()}
+	for _, file := range stored {
+		store.entries[file.name] = store.recent.PushFront(file.storeEntry)
+		store.bytes += file.size
+	}
+
+	store.mu.Lock()
+	err = store.evictLocked()
+	store.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	return store, nil
+}
+
+func (store *FileStore) path(name string) string {
+	return filepath.Join(store.dir, name)
+}
+
+// Get returns the stored response of a call, if any
+func (store *FileStore) Get(key StoreKey) (CallResponse, bool, error) {
+	name := hex.EncodeToString(key.Hash().Bytes())
+
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	element, ok := store.entries[name]
+	if !ok {
+		store.misses++
+		return CallResponse{}, false, nil
+	}
+
+	data, err := os.ReadFile(store.path(name))
+	if err != nil || len(data) == 0 {
+		// The file went missing or is corrupted, forget it
+		store.removeLocked(element)
+		store.misses++
+		if errors.Is(err, os.ErrNotExist) {
+			err = nil
+		}
+		return CallResponse{}, false, err
+	}
+
+	store.recent.MoveToFront(element)
+	now := time.Now()
+	os.Chtimes(store.path(name), now, now)
+	store.hits++
+
+	return CallResponse{Success: data[0] == 1, ReturnData: data[1:]}, true, nil
+}
+
+// Put stores the response of a call, evicting the least recently used responses when a limit is exceeded
+func (store *FileStore) Put(key StoreKey, response CallResponse) error {
+	name := hex.EncodeToString(key.Hash().Bytes())
+
+	data := make([]byte, 1+len(response.ReturnData))
+	if response.Success {
+		data[0] = 1
+	}
+	copy(data[1:], response.ReturnData)
+
+	if int64(len(data)) > store.options.MaxBytes {
+		return nil
+	}
+
+	// Write to a temporary file first so a crash never leaves a truncated response behind
+	file, err := os.CreateTemp(store.dir, ".tmp-")
+	if err != nil {
+		return err
+	}
+	_, err = file.Write(data)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(file.Name())
+		return err
+	}
+
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	if err := os.Rename(file.Name(), store.path(name)); err != nil {
+		os.Remove(file.Name())
+		return err
+	}
+
+	if element, ok := store.entries[name]; ok {
+		store.bytes -= element.Value.(storeEntry).size
+		store.recent.Remove(element)
+	}
+	store.entries[name] = store.recent.PushFront(storeEntry{name: name, size: int64(len(data))})
+	store.bytes += int64(len(data))
+
+	return store.evictLocked()
+}
+
+func (store *FileStore) removeLocked(element *list.Element) {
+	entry := store.recent.Remove(element).(storeEntry)
+	delete(store.entries, entry.name)
+	store.bytes -= entry.size
+}
+
+// evictLocked removes the least recently used responses until the store is within its limits
+func (store *FileStore) evictLocked() error {
+	for store.bytes > store.options.MaxBytes || (store.options.MaxEntries > 0 && len(store.entries) > store.options.MaxEntries) {
+		element := store.recent.Back()
+		name := element.Value.(storeEntry).name
+		store.removeLocked(element)
+		store.evictions++
+
+		if err := os.Remove(store.path(name)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Stats returns the hit, miss and eviction counters since the store was opened, along with its current size
+func (store *FileStore) Stats() StoreStats {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	return StoreStats{Hits: store.hits, Misses: store.misses, Evictions: store.evictions, Entries: len(store.entries), Bytes: store.bytes}
+}
+
+// isFinalized reports whether a block can no longer be reorganized. The finalized block number is remembered and
+// only fetched again for blocks past it.
+func (caller *EthMultiCaller) isFinalized(ctx context.Context, number uint64) (bool, error) {
+	if finalized, ok := caller.finalized.Load().(uint64); ok && number <= finalized {
+		return true, nil
+	}
+
+	finalized, err := caller.finalizedNumber(ctx)
+	if err != nil {
+		return false, err
+	}
+	caller.finalized.Store(finalized)
+
+	return number <= finalized, nil
+}
+
+// finalizedNumber returns the number of the last finalized block. Nodes that do not know the finalized tag are
+// assumed to finalize DefaultReorgDepth blocks behind the head.
+func (caller *EthMultiCaller) finalizedNumber(ctx context.Context) (uint64, error) {
+	if rpcClient := caller.rpcClient(ctx); rpcClient != nil && caller.limiter(ctx).Wait(ctx, "eth_getBlockByNumber", 1) == nil {
+		var header *types.Header
+		if err := rpcClient.CallContext(ctx, &header, "eth_getBlockByNumber", "finalized", false); err == nil && header != nil {
+			return header.Number.Uint64(), nil
+		}
+	}
+
+	head, err := caller.headerByNumber(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	if head.Number.Uint64() < DefaultReorgDepth {
+		return 0, nil
+	}
+
+	return head.Number.Uint64() - DefaultReorgDepth, nil
+}
+
+// executeStored serves what it can of the calls from the store and executes the rest at the same block, storing
+// their responses. It reports false without doing anything when the block is not finalized yet.
+func (caller *EthMultiCaller) executeStored(ctx context.Context, calls []Call, header *blockHeader) ([]CallResponse, bool, error) {
+	finalized, err := caller.isFinalized(ctx, header.Number.Uint64())
+	if err != nil || !finalized {
+		return nil, false, err
+	}
+
+	chainID, err := caller.chainIDUint64(ctx)
+	if err != nil {
+		return nil, true, err
+	}
+	blockHash := header.Hash()
+
+	responses := make([]CallResponse, len(calls))
+	var missing []Call
+	var missingIndexes []int
+	for i, call := range calls {
+		if response, ok, err := caller.Store.Get(newStoreKey(chainID, blockHash, call)); ok && err == nil {
+			responses[i] = response
+			continue
+		}
+		missing = append(missing, call)
+		missingIndexes = append(missingIndexes, i)
+	}
+
+	if len(missing) == 0 {
+		return responses, true, nil
+	}
+
+	missingResponses, err := caller.tryAggregateAtHash(ctx, missing, blockHash)
+	if err != nil {
+		return nil, true, err
+	}
+
+	for i, response := range missingResponses {
+		responses[missingIndexes[i]] = response
+		// Calls that poisoned their aggregate failed for want of gas or response size rather than by the state of
+		// the block, and the store does not keep the error, so they are not stored
+		if response.Error != "" {
+			continue
+		}
+		// A failing store only costs the next read a round trip
+		_ = caller.Store.Put(newStoreKey(chainID, blockHash, missing[i]), response)
+	}
+
+	return responses, true, nil
+}
@@ -0,0 +1,56 @@
+package go_eth_multicall
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	MultiCall2 "github.com/truongpx396/go-eth-multicall/contracts/MultiCall"
+)
+
+// mapStore is a ResultStore in memory
+type mapStore map[common.Hash]CallResponse
+
+func (store mapStore) Get(key StoreKey) (CallResponse, bool, error) {
+	response, ok := store[key.Hash()]
+	return response, ok, nil
+}
+
+func (store mapStore) Put(key StoreKey, response CallResponse) error {
+	store[key.Hash()] = response
+	return nil
+}
+
+func TestStoreSkipsErrors(t *testing.T) {
+	node, caller := newFakeNode(t)
+	store := mapStore{}
+	caller.Store = store
+
+	calls := bisectCalls(4)
+	// The aggregates holding the third call run out of gas
+	node.fail = func(attempt int, aggregated []MultiCall2.Multicall2Call) error {
+		for _, call := range aggregated {
+			if bytes.Equal(call.CallData, calls[2].CallData) {
+				return errors.New("out of gas")
+			}
+		}
+		return nil
+	}
+
+	responses, err := caller.ExecuteAtHash(context.Background(), calls, node.headHash())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if responses[2].Success || responses[2].Error == "" {
+		t.Fatalf("got %+v for the poisoner", responses[2])
+	}
+
+	if len(store) != 3 {
+		t.Fatalf("stored %d responses, want the 3 without an error", len(store))
+	}
+	if _, ok := store[newStoreKey(1, node.headHash(), calls[2]).Hash()]; ok {
+		t.Fatal("stored the response of the poisoner")
+	}
+}
@@ -0,0 +1,158 @@
+package go_eth_multicall
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// CallTrace is a frame of the call tree of a failed call, as recorded by the callTracer of debug_traceCall
+type CallTrace struct {
+	// Type is the kind of call, CALL, STATICCALL, DELEGATECALL, CREATE...
+	Type   string         `json:"type"`
+	From   common.Address `json:"from"`
+	Target common.Address `json:"target"`
+	// Selector is the first 4 bytes of the input, empty when the input is shorter
+	Selector hexutil.Bytes `json:"selector,omitempty"`
+	Input    hexutil.Bytes `json:"input,omitempty"`
+	Gas      uint64        `json:"gas"`
+	GasUsed  uint64        `json:"gasUsed"`
+	// Error is why the frame failed, e.g. "execution reverted" or "out of gas"
+	Error        string        `json:"error,omitempty"`
+	RevertData   hexutil.Bytes `json:"revertData,omitempty"`
+	RevertReason string        `json:"revertReason,omitempty"`
+	// Depth is 0 for the call itself, 1 for the calls it made and so on
+	Depth int         `json:"depth"`
+	Calls []CallTrace `json:"calls,omitempty"`
+}
+
+// Failed returns the deepest frames that failed, where the failure started
+func (trace CallTrace) Failed() []CallTrace {
+	var failed []CallTrace
+	for _, call := range trace.Calls {
+		failed = append(failed, call.Failed()...)
+	}
+	if len(failed) == 0 && trace.Error != "" {
+		failed = append(failed, trace)
+	}
+
+	return failed
+}
+
+// callFrame is a frame as returned by the callTracer
+type callFrame struct {
+	Type         string         `json:"type"`
+	From         common.Address `json:"from"`
+	To           common.Address `json:"to"`
+	Gas          hexutil.Uint64 `json:"gas"`
+	GasUsed      hexutil.Uint64 `json:"gasUsed"`
+	Input        hexutil.Bytes  `json:"input"`
+	Output       hexutil.Bytes  `json:"output"`
+	Error        string         `json:"error"`
+	RevertReason string         `json:"revertReason"`
+	Calls        []callFrame    `json:"calls"`
+}
+
+func (frame callFrame) decode(depth int) CallTrace {
+	trace := CallTrace{
+		Type:         frame.Type,
+		From:         frame.From,
+		Target:       frame.To,
+		Input:        frame.Input,
+		Gas:          uint64(frame.Gas),
+		GasUsed:      uint64(frame.GasUsed),
+		Error:        frame.Error,
+		RevertReason: frame.RevertReason,
+		Depth:        depth,
+	}
+	if len(frame.Input) >= 4 {
+		trace.Selector = frame.Input[:4]
+	}
+	if frame.Error != "" {
+		trace.RevertData = frame.Output
+	}
+	for _, call := range frame.Calls {
+		trace.Calls = append(trace.Calls, call.decode(depth+1))
+	}
+
+	return trace
+}
+
+// blockArg is the JSON-RPC block parameter for either a block number, nil meaning the latest block, or a block hash
+func blockArg(blockNumber *big.Int, blockHash *common.Hash) interface{} {
+	if blockHash != nil {
+		return rpc.BlockNumberOrHashWithHash(*blockHash, false)
+	}
+	if blockNumber != nil {
+		return hexutil.EncodeBig(blockNumber)
+	}
+
+	return "latest"
+}
+
+// traceFailures re-runs the unsuccessful calls through debug_traceCall at the given block and attaches their call
+// tree to the responses. Executions at the latest block are pinned to a block hash when tracing, so block is the
+// block the calls ran at. The calls are traced in a single JSON-RPC batch, sent from the multicall contract as
+// the aggregate does. Tracing is best effort: when the node does not trace, the responses are left as they are.
+func (caller *EthMultiCaller) traceFailures(ctx context.Context, calls []Call, responses []CallResponse, block interface{}) {
+	if !caller.TraceFailures {
+		return
+	}
+	rpcClient := caller.rpcClient(ctx)
+	if rpcClient == nil {
+		return
+	}
+
+	var indexes []int
+	for i, response := range responses {
+		if !response.Success {
+			indexes = append(indexes, i)
+		}
+	}
+	if len(indexes) == 0 {
+		return
+	}
+
+	frames := make([]callFrame, len(indexes))
+	batch := make([]rpc.BatchElem, len(indexes))
+	for i, index := range indexes {
+		msg := map[string]interface{}{"to": calls[index].Target, "data": hexutil.Bytes(calls[index].CallData)}
+		if caller.Strategy != StrategyBatch {
+			msg["from"] = caller.multicall(ctx)
+		}
+		batch[i] = rpc.BatchElem{
+			Method: "debug_traceCall",
+			Args:   []interface{}{msg, block, map[string]interface{}{"tracer": "callTracer"}},
+			Result: &frames[i],
+		}
+	}
+
+	err := caller.withRetry(ctx, func() error {
+		if err := caller.limiter(ctx).Wait(ctx, "debug_traceCall", len(batch)); err != nil {
+			return err
+		}
+		for i := range batch {
+			batch[i].Error = nil
+		}
+
+		return rpcClient.BatchCallContext(ctx, batch)
+	})
+	if err != nil {
+		log.Warn("Failed to trace failed calls", "calls", len(indexes), "err", err)
+		return
+	}
+
+	for i, index := range indexes {
+		if batch[i].Error != nil {
+			log.Debug("Failed to trace failed call", "target", calls[index].Target, "err", batch[i].Error)
+			continue
+		}
+
+		trace := frames[i].decode(0)
+		responses[index].Trace = &trace
+	}
+}
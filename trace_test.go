@@ -0,0 +1,57 @@
+package go_eth_multicall
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	MultiCall2 "github.com/truongpx396/go-eth-multicall/contracts/MultiCall"
+)
+
+func TestTraceFailuresAtLatestBlock(t *testing.T) {
+	node, caller := newFakeNode(t)
+	caller.TraceFailures = true
+
+	calls := bisectCalls(3)
+	node.answer = func(attempt int, call MultiCall2.Multicall2Call) (fakeResult, bool) {
+		return fakeResult{Success: false}, bytes.Equal(call.CallData, calls[1].CallData)
+	}
+
+	responses, err := caller.ExecuteContext(context.Background(), calls, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if responses[1].Success || responses[1].Trace == nil || len(responses[1].Trace.Failed()) != 1 {
+		t.Fatalf("got %+v, want a traced failure", responses[1])
+	}
+	if responses[0].Trace != nil || responses[2].Trace != nil {
+		t.Fatal("traced a successful call")
+	}
+
+	// The trace runs at the block the aggregate ran at, not at whatever is the latest block by then
+	if len(node.traced) != 1 {
+		t.Fatalf("got %d traces, want 1", len(node.traced))
+	}
+	if hash, ok := node.traced[0].Hash(); !ok || hash != node.headHash() {
+		t.Fatalf("traced at %v, want the head hash %s", node.traced[0], node.headHash().Hex())
+	}
+}
+
+func TestCallTraceFailed(t *testing.T) {
+	trace := CallTrace{Error: "execution reverted", Calls: []CallTrace{
+		{Target: common.HexToAddress("0x01")},
+		{Target: common.HexToAddress("0x02"), Error: "execution reverted", Calls: []CallTrace{
+			{Target: common.HexToAddress("0x03"), Error: "out of gas"},
+		}},
+	}}
+
+	failed := trace.Failed()
+	if len(failed) != 1 || failed[0].Target != common.HexToAddress("0x03") {
+		t.Fatalf("got %+v, want the deepest failed frame", failed)
+	}
+	if failed := (CallTrace{Error: "execution reverted"}).Failed(); len(failed) != 1 {
+		t.Fatalf("got %d frames, want the call itself", len(failed))
+	}
+}
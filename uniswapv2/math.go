@@ -0,0 +1,124 @@
+package uniswapv2
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// DefaultFeeBps is the swap fee of Uniswap V2 itself, forks often charge a different one
+const DefaultFeeBps = 30
+
+var (
+	ErrInsufficientInputAmount  = errors.New("uniswapv2: insufficient input amount")
+	ErrInsufficientOutputAmount = errors.New("uniswapv2: insufficient output amount")
+	ErrInsufficientLiquidity    = errors.New("uniswapv2: insufficient liquidity")
+	ErrUnknownToken             = errors.New("uniswapv2: token is not part of the pair")
+	ErrInvalidFee               = errors.New("uniswapv2: fee must be below 10000 bps")
+)
+
+var bpsDenominator = big.NewInt(10000)
+
+// GetAmountOut returns the output of swapping amountIn against the reserves, with the same integer
+// rounding as UniswapV2Library.getAmountOut
+func GetAmountOut(amountIn, reserveIn, reserveOut *big.Int, feeBps uint64) (*big.Int, error) {
+	if feeBps >= bpsDenominator.Uint64() {
+		return nil, ErrInvalidFee
+	}
+	if amountIn == nil || amountIn.Sign() <= 0 {
+		return nil, ErrInsufficientInputAmount
+	}
+	if reserveIn == nil || reserveOut == nil || reserveIn.Sign() <= 0 || reserveOut.Sign() <= 0 {
+		return nil, ErrInsufficientLiquidity
+	}
+
+	amountInWithFee := new(big.Int).Mul(amountIn, new(big.Int).Sub(bpsDenominator, new(big.Int).SetUint64(feeBps)))
+	numerator := new(big.Int).Mul(amountInWithFee, reserveOut)
+	denominator := new(big.Int).Mul(reserveIn, bpsDenominator)
+	denominator.Add(denominator, amountInWithFee)
+
+	return numerator.Quo(numerator, denominator), nil
+}
+
+// GetAmountIn returns the input required to receive amountOut from the reserves, with the same integer
+// rounding as UniswapV2Library.getAmountIn
+func GetAmountIn(amountOut, reserveIn, reserveOut *big.Int, feeBps uint64) (*big.Int, error) {
+	if feeBps >= bpsDenominator.Uint64() {
+		return nil, ErrInvalidFee
+	}
+	if amountOut == nil || amountOut.Sign() <= 0 {
+		return nil, ErrInsufficientOutputAmount
+	}
+	if reserveIn == nil || reserveOut == nil || reserveIn.Sign() <= 0 || reserveOut.Cmp(amountOut) <= 0 {
+		return nil, ErrInsufficientLiquidity
+	}
+
+	numerator := new(big.Int).Mul(reserveIn, amountOut)
+	numerator.Mul(numerator, bpsDenominator)
+	denominator := new(big.Int).Sub(reserveOut, amountOut)
+	denominator.Mul(denominator, new(big.Int).Sub(bpsDenominator, new(big.Int).SetUint64(feeBps)))
+
+	amountIn := numerator.Quo(numerator, denominator)
+	return amountIn.Add(amountIn, big.NewInt(1)), nil
+}
+
+// Price0 is the spot price of token0 in token1, in raw units. It is nil when the pair has no reserves
+func (pair Pair) Price0() *big.Rat {
+	if pair.Reserve0 == nil || pair.Reserve0.Sign() == 0 {
+		return nil
+	}
+
+	return new(big.Rat).SetFrac(pair.Reserve1, pair.Reserve0)
+}
+
+// Price1 is the spot price of token1 in token0, in raw units. It is nil when the pair has no reserves
+func (pair Pair) Price1() *big.Rat {
+	if pair.Reserve1 == nil || pair.Reserve1.Sign() == 0 {
+		return nil
+	}
+
+	return new(big.Rat).SetFrac(pair.Reserve0, pair.Reserve1)
+}
+
+// AdjustPrice converts a raw price of base in quote into whole token units
+func AdjustPrice(price *big.Rat, baseDecimals, quoteDecimals uint8) *big.Rat {
+	if price == nil {
+		return nil
+	}
+
+	scale := new(big.Rat).SetFrac(
+		new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(baseDecimals)), nil),
+		new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(quoteDecimals)), nil),
+	)
+
+	return scale.Mul(scale, price)
+}
+
+// Quote returns the output of swapping amountIn of tokenIn through the pair.
+// A pair whose reserves were not read fails with ErrInsufficientLiquidity.
+func (pair Pair) Quote(tokenIn common.Address, amountIn *big.Int, feeBps uint64) (*big.Int, error) {
+	switch tokenIn {
+	case pair.Token0:
+		return GetAmountOut(amountIn, pair.Reserve0, pair.Reserve1, feeBps)
+	case pair.Token1:
+		return GetAmountOut(amountIn, pair.Reserve1, pair.Reserve0, feeBps)
+	}
+
+	return nil, ErrUnknownToken
+}
+
+// LiquidityValue returns the reserves that burning the given amount of liquidity tokens would return.
+// The protocol fee that is minted on burn is not taken into account.
+func (pair Pair) LiquidityValue(liquidity *big.Int) (amount0, amount1 *big.Int) {
+	if pair.TotalSupply == nil || pair.TotalSupply.Sign() == 0 {
+		return new(big.Int), new(big.Int)
+	}
+
+	amount0 = new(big.Int).Mul(liquidity, pair.Reserve0)
+	amount0.Quo(amount0, pair.TotalSupply)
+	amount1 = new(big.Int).Mul(liquidity, pair.Reserve1)
+	amount1.Quo(amount1, pair.TotalSupply)
+
+	return amount0, amount1
+}
@@ -0,0 +1,90 @@
+package uniswapv2
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func ether(amount int64) *big.Int {
+	return new(big.Int).Mul(big.NewInt(amount), big.NewInt(1e18))
+}
+
+func TestGetAmountOut(t *testing.T) {
+	// The getInputPrice vectors of the UniswapV2Pair tests, at the 0.3% fee
+	tests := []struct {
+		amountIn, reserveIn, reserveOut *big.Int
+		want                            string
+	}{
+		{ether(1), ether(5), ether(10), "1662497915624478906"},
+		{ether(1), ether(10), ether(5), "453305446940074565"},
+		{ether(2), ether(5), ether(10), "2851015155847869602"},
+		{ether(2), ether(10), ether(5), "831248957812239453"},
+		{ether(1), ether(10), ether(10), "906610893880149131"},
+		{ether(1), ether(100), ether(100), "987158034397061298"},
+		{ether(1), ether(1000), ether(1000), "996006981039903216"},
+		// The UniswapV2Router tests
+		{big.NewInt(2), big.NewInt(100), big.NewInt(100), "1"},
+	}
+
+	for _, test := range tests {
+		got, err := GetAmountOut(test.amountIn, test.reserveIn, test.reserveOut, DefaultFeeBps)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.String() != test.want {
+			t.Errorf("%s in against %s/%s: got %s, want %s", test.amountIn, test.reserveIn, test.reserveOut, got, test.want)
+		}
+	}
+}
+
+func TestGetAmountIn(t *testing.T) {
+	// The UniswapV2Router tests
+	got, err := GetAmountIn(big.NewInt(1), big.NewInt(100), big.NewInt(100), DefaultFeeBps)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Int64() != 2 {
+		t.Fatalf("got %s, want 2", got)
+	}
+
+	// The input returned always buys at least the output asked for
+	for _, amountOut := range []*big.Int{big.NewInt(1), big.NewInt(997), ether(1), ether(3)} {
+		amountIn, err := GetAmountIn(amountOut, ether(5), ether(10), DefaultFeeBps)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		out, _ := GetAmountOut(amountIn, ether(5), ether(10), DefaultFeeBps)
+		if out.Cmp(amountOut) < 0 {
+			t.Errorf("%s in buys %s, want at least %s", amountIn, out, amountOut)
+		}
+	}
+}
+
+func TestAmountErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{name: "zero in", err: second(GetAmountOut(new(big.Int), ether(1), ether(1), DefaultFeeBps)), want: ErrInsufficientInputAmount},
+		{name: "empty reserve in", err: second(GetAmountOut(ether(1), new(big.Int), ether(1), DefaultFeeBps)), want: ErrInsufficientLiquidity},
+		{name: "zero out", err: second(GetAmountIn(new(big.Int), ether(1), ether(1), DefaultFeeBps)), want: ErrInsufficientOutputAmount},
+		{name: "out of reserve", err: second(GetAmountIn(ether(1), ether(1), ether(1), DefaultFeeBps)), want: ErrInsufficientLiquidity},
+		{name: "fee above 100%", err: second(GetAmountOut(ether(1), ether(1), ether(1), 10001)), want: ErrInvalidFee},
+		{name: "fee of 100%", err: second(GetAmountIn(ether(1), ether(5), ether(10), 10000)), want: ErrInvalidFee},
+		{name: "unread reserves", err: second(Pair{}.Quote(common.Address{}, ether(1), DefaultFeeBps)), want: ErrInsufficientLiquidity},
+	}
+
+	for _, test := range tests {
+		if test.err != test.want {
+			t.Errorf("%s: got %v, want %v", test.name, test.err, test.want)
+		}
+	}
+}
+
+func second(_ *big.Int, err error) error {
+	return err
+}
@@ -0,0 +1,199 @@
+// Package uniswapv2 reads Uniswap V2 compatible pairs and factories through an EthMultiCaller.
+package uniswapv2
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	multicall "github.com/truongpx396/go-eth-multicall"
+	"github.com/truongpx396/go-eth-multicall/contracts/IUniswapV2Factory"
+	"github.com/truongpx396/go-eth-multicall/contracts/IUniswapV2Pair"
+)
+
+var (
+	pairAbi    = multicall.MustParseABI(IUniswapV2Pair.IUniswapV2PairABI)
+	factoryAbi = multicall.MustParseABI(IUniswapV2Factory.IUniswapV2FactoryABI)
+)
+
+// Pair is the state of a single pair
+type Pair struct {
+	Address            common.Address
+	Token0             common.Address
+	Token1             common.Address
+	Reserve0           *big.Int
+	Reserve1           *big.Int
+	BlockTimestampLast uint32
+	TotalSupply        *big.Int
+	// Err is set when the pair did not answer the pair calls, in which case the other fields are incomplete
+	Err error
+}
+
+// Position is a holder's liquidity in a pair, together with the reserves it is redeemable for
+type Position struct {
+	Pair      common.Address
+	Holder    common.Address
+	Liquidity *big.Int
+	Amount0   *big.Int
+	Amount1   *big.Int
+}
+
+// TokenPair is an unordered pair of tokens to look up in a factory
+type TokenPair struct {
+	TokenA common.Address
+	TokenB common.Address
+}
+
+// Snapshot is the state of all requested pairs and positions, read at a single block
+type Snapshot struct {
+	BlockNumber uint64
+	Pairs       []Pair
+	Positions   []Position
+}
+
+// PairCalls returns the calls reading the reserves, tokens and supply of a pair
+func PairCalls(pair common.Address) []multicall.Call {
+	return []multicall.Call{
+		multicall.NewCall(pairAbi, pair, "getReserves"),
+		multicall.NewCall(pairAbi, pair, "token0"),
+		multicall.NewCall(pairAbi, pair, "token1"),
+		multicall.NewCall(pairAbi, pair, "totalSupply"),
+	}
+}
+
+// DecodePair builds a Pair out of the responses to PairCalls
+func DecodePair(pair common.Address, responses map[string]multicall.CallResponse) Pair {
+	result := Pair{Address: pair}
+
+	reserves, err := multicall.Unpack(pairAbi, responses, pair, "getReserves")
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	token0, err := multicall.Unpack(pairAbi, responses, pair, "token0")
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	token1, err := multicall.Unpack(pairAbi, responses, pair, "token1")
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	totalSupply, err := multicall.Unpack(pairAbi, responses, pair, "totalSupply")
+	if err != nil {
+		result.Err = err
+		return result
+	}
+
+	result.Reserve0 = reserves[0].(*big.Int)
+	result.Reserve1 = reserves[1].(*big.Int)
+	result.BlockTimestampLast = reserves[2].(uint32)
+	result.Token0 = token0[0].(common.Address)
+	result.Token1 = token1[0].(common.Address)
+	result.TotalSupply = totalSupply[0].(*big.Int)
+
+	return result
+}
+
+// ReadPairs reads the state of every pair and the liquidity of every holder in each pair in a single multicall.
+// An error is returned when the block number call failed.
+func ReadPairs(caller *multicall.EthMultiCaller, pairs []common.Address, holders []common.Address) (Snapshot, error) {
+	return readPairs(caller, pairs, holders, nil)
+}
+
+func readPairs(caller *multicall.EthMultiCaller, pairs []common.Address, holders []common.Address, blockNumber *big.Int) (Snapshot, error) {
+	const blockNumberName = "blockNumber"
+
+	calls := []multicall.Call{caller.GetBlockNumberCall(blockNumberName)}
+	for _, pair := range pairs {
+		calls = append(calls, PairCalls(pair)...)
+		for _, holder := range holders {
+			calls = append(calls, multicall.NewCall(pairAbi, pair, "balanceOf", holder))
+		}
+	}
+
+	responses := caller.ExecuteAtBlock(calls, blockNumber)
+	executedAt, err := multicall.UnpackBlockNumber(responses, blockNumberName)
+	if err != nil {
+		return Snapshot{}, err
+	}
+
+	snapshot := Snapshot{
+		BlockNumber: executedAt.Uint64(),
+		Pairs:       make([]Pair, 0, len(pairs)),
+	}
+	for _, pair := range pairs {
+		state := DecodePair(pair, responses)
+		snapshot.Pairs = append(snapshot.Pairs, state)
+		if state.Err != nil {
+			continue
+		}
+
+		for _, holder := range holders {
+			balance, err := multicall.Unpack(pairAbi, responses, pair, "balanceOf", holder)
+			if err != nil {
+				continue
+			}
+
+			position := Position{Pair: pair, Holder: holder, Liquidity: balance[0].(*big.Int)}
+			position.Amount0, position.Amount1 = state.LiquidityValue(position.Liquidity)
+			snapshot.Positions = append(snapshot.Positions, position)
+		}
+	}
+
+	return snapshot, nil
+}
+
+// DiscoverPairs looks up the pair of every token pair in a factory.
+// Token pairs without a pair are left out of the returned map.
+func DiscoverPairs(caller *multicall.EthMultiCaller, factory common.Address, tokenPairs []TokenPair) (map[TokenPair]common.Address, error) {
+	pairs, _, err := discoverPairs(caller, factory, tokenPairs)
+	return pairs, err
+}
+
+func discoverPairs(caller *multicall.EthMultiCaller, factory common.Address, tokenPairs []TokenPair) (map[TokenPair]common.Address, *big.Int, error) {
+	const blockNumberName = "blockNumber"
+
+	calls := []multicall.Call{caller.GetBlockNumberCall(blockNumberName)}
+	for _, tokenPair := range tokenPairs {
+		calls = append(calls, multicall.NewCall(factoryAbi, factory, "getPair", tokenPair.TokenA, tokenPair.TokenB))
+	}
+
+	responses := caller.Execute(calls)
+	blockNumber, err := multicall.UnpackBlockNumber(responses, blockNumberName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pairs := make(map[TokenPair]common.Address)
+	for _, tokenPair := range tokenPairs {
+		pair, err := multicall.Unpack(factoryAbi, responses, factory, "getPair", tokenPair.TokenA, tokenPair.TokenB)
+		if err != nil {
+			continue
+		}
+		if address := pair[0].(common.Address); address != (common.Address{}) {
+			pairs[tokenPair] = address
+		}
+	}
+
+	return pairs, blockNumber, nil
+}
+
+// ReadFactoryPairs discovers the pairs of the token pairs in a factory and reads their state and the
+// liquidity of every holder. The read is pinned to the block the discovery ran in.
+func ReadFactoryPairs(caller *multicall.EthMultiCaller, factory common.Address, tokenPairs []TokenPair, holders []common.Address) (map[TokenPair]common.Address, Snapshot, error) {
+	discovered, blockNumber, err := discoverPairs(caller, factory, tokenPairs)
+	if err != nil {
+		return nil, Snapshot{}, err
+	}
+
+	pairs := make([]common.Address, 0, len(discovered))
+	for _, tokenPair := range tokenPairs {
+		if pair, ok := discovered[tokenPair]; ok {
+			pairs = append(pairs, pair)
+		}
+	}
+
+	snapshot, err := readPairs(caller, pairs, holders, blockNumber)
+	return discovered, snapshot, err
+}
@@ -0,0 +1,154 @@
+package uniswapv3
+
+import (
+	"errors"
+	"math/big"
+)
+
+const (
+	// MinTick and MaxTick bound the ticks a pool can use, see TickMath.sol
+	MinTick = -887272
+	MaxTick = 887272
+)
+
+var ErrTickOutOfRange = errors.New("uniswapv3: tick out of range")
+
+var (
+	q96  = new(big.Int).Lsh(big.NewInt(1), 96)
+	q192 = new(big.Int).Lsh(big.NewInt(1), 192)
+
+	maxUint256 = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+
+	// sqrtRatioFactors are the multipliers of TickMath.getSqrtRatioAtTick, indexed by the bit of the tick they apply to
+	sqrtRatioFactors = []*big.Int{
+		hexBig("fffcb933bd6fad37aa2d162d1a594001"),
+		hexBig("fff97272373d413259a46990580e213a"),
+		hexBig("fff2e50f5f656932ef12357cf3c7fdcc"),
+		hexBig("ffe5caca7e10e4e61c3624eaa0941cd0"),
+		hexBig("ffcb9843d60f6159c9db58835c926644"),
+		hexBig("ff973b41fa98c081472e6896dfb254c0"),
+		hexBig("ff2ea16466c96a3843ec78b326b52861"),
+		hexBig("fe5dee046a99a2a811c461f1969c3053"),
+		hexBig("fcbe86c7900a88aedcffc83b479aa3a4"),
+		hexBig("f987a7253ac413176f2b074cf7815e54"),
+		hexBig("f3392b0822b70005940c7a398e4b70f3"),
+		hexBig("e7159475a2c29b7443b29c7fa6e889d9"),
+		hexBig("d097f3bdfd2022b8845ad8f792aa5825"),
+		hexBig("a9f746462d870fdf8a65dc1f90e061e5"),
+		hexBig("70d869a156d2a1b890bb3df62baf32f7"),
+		hexBig("31be135f97d08fd981231505542fcfa6"),
+		hexBig("9aa508b5b7a84e1c677de54f3e99bc9"),
+		hexBig("5d6af8dedb81196699c329225ee604"),
+		hexBig("2216e584f5fa1ea926041bedfe98"),
+		hexBig("48a170391f7dc42444e8fa2"),
+	}
+)
+
+func hexBig(hex string) *big.Int {
+	value, ok := new(big.Int).SetString(hex, 16)
+	if !ok {
+		panic("uniswapv3: invalid constant " + hex)
+	}
+
+	return value
+}
+
+func pow10(decimals uint8) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+}
+
+// TickToSqrtPriceX96 returns sqrt(1.0001^tick) as a Q64.96, with the same rounding as TickMath.getSqrtRatioAtTick
+func TickToSqrtPriceX96(tick int32) (*big.Int, error) {
+	if tick < MinTick || tick > MaxTick {
+		return nil, ErrTickOutOfRange
+	}
+
+	absTick := tick
+	if absTick < 0 {
+		absTick = -absTick
+	}
+
+	ratio := new(big.Int).Lsh(big.NewInt(1), 128)
+	if absTick&1 != 0 {
+		ratio.Set(sqrtRatioFactors[0])
+	}
+	for bit := 1; bit < len(sqrtRatioFactors); bit++ {
+		if absTick&(1<<bit) != 0 {
+			ratio.Mul(ratio, sqrtRatioFactors[bit])
+			ratio.Rsh(ratio, 128)
+		}
+	}
+	if tick > 0 {
+		ratio.Quo(maxUint256, ratio)
+	}
+
+	// Round up when going from Q128.128 to Q64.96
+	remainder := new(big.Int).And(ratio, big.NewInt(0xffffffff))
+	ratio.Rsh(ratio, 32)
+	if remainder.Sign() != 0 {
+		ratio.Add(ratio, big.NewInt(1))
+	}
+
+	return ratio, nil
+}
+
+// SqrtPriceX96ToPrice returns the price of token0 in token1 in whole token units
+func SqrtPriceX96ToPrice(sqrtPriceX96 *big.Int, decimals0, decimals1 uint8) *big.Rat {
+	// price = sqrtPriceX96^2 / 2^192 * 10^decimals0 / 10^decimals1
+	numerator := new(big.Int).Mul(sqrtPriceX96, sqrtPriceX96)
+	numerator.Mul(numerator, pow10(decimals0))
+	denominator := new(big.Int).Mul(q192, pow10(decimals1))
+
+	return new(big.Rat).SetFrac(numerator, denominator)
+}
+
+// TickToPrice returns the price of token0 in token1 at a tick in whole token units
+func TickToPrice(tick int32, decimals0, decimals1 uint8) (*big.Rat, error) {
+	sqrtPriceX96, err := TickToSqrtPriceX96(tick)
+	if err != nil {
+		return nil, err
+	}
+
+	return SqrtPriceX96ToPrice(sqrtPriceX96, decimals0, decimals1), nil
+}
+
+// TimeWeightedAverageTick returns the average tick between two tick cumulatives observed window seconds
+// apart, rounded towards negative infinity like OracleLibrary.consult
+func TimeWeightedAverageTick(tickCumulativeStart, tickCumulativeEnd *big.Int, window uint32) int32 {
+	delta := new(big.Int).Sub(tickCumulativeEnd, tickCumulativeStart)
+	divisor := big.NewInt(int64(window))
+
+	tick, remainder := new(big.Int).QuoRem(delta, divisor, new(big.Int))
+	if delta.Sign() < 0 && remainder.Sign() != 0 {
+		tick.Sub(tick, big.NewInt(1))
+	}
+
+	return int32(tick.Int64())
+}
+
+// compressTick returns the tick divided by the spacing, rounded towards negative infinity
+func compressTick(tick, tickSpacing int32) int32 {
+	compressed := tick / tickSpacing
+	if tick < 0 && tick%tickSpacing != 0 {
+		compressed--
+	}
+
+	return compressed
+}
+
+// tickWord returns the position of the tick bitmap word holding the given tick
+func tickWord(tick, tickSpacing int32) int16 {
+	return int16(compressTick(tick, tickSpacing) >> 8)
+}
+
+// initializedTicks returns the ticks flagged in a tick bitmap word, in ascending order
+func initializedTicks(wordPosition int16, word *big.Int, tickSpacing int32) []int32 {
+	var ticks []int32
+	for bit := 0; bit < 256; bit++ {
+		if word.Bit(bit) == 1 {
+			ticks = append(ticks, (int32(wordPosition)<<8+int32(bit))*tickSpacing)
+		}
+	}
+
+	return ticks
+}
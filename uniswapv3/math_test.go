@@ -0,0 +1,121 @@
+package uniswapv3
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestTickToSqrtPriceX96(t *testing.T) {
+	// The reference values of the TickMath tests
+	tests := []struct {
+		tick int32
+		want string
+	}{
+		{tick: MinTick, want: "4295128739"},
+		{tick: MinTick + 1, want: "4295343490"},
+		{tick: 0, want: "79228162514264337593543950336"},
+		{tick: MaxTick - 1, want: "1461373636630004318706518188784493106690254656249"},
+		{tick: MaxTick, want: "1461446703485210103287273052203988822378723970342"},
+	}
+
+	for _, test := range tests {
+		got, err := TickToSqrtPriceX96(test.tick)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.String() != test.want {
+			t.Errorf("tick %d: got %s, want %s", test.tick, got, test.want)
+		}
+	}
+
+	// Every bit of the tick against sqrt(1.0001^tick) in floating point
+	for _, tick := range []int32{-1, 1, 2, 4, 8, 16, 32, 64, 128, 256, 512, 1024, 2048, 4096, 8192, 16384, 32768, 65536, 131072, 262144, 524288, -50, 50, 100, -100, 1000, -1000, 200000, -200000} {
+		got, err := TickToSqrtPriceX96(tick)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := math.Pow(1.0001, float64(tick)/2) * math.Pow(2, 96)
+		value, _ := new(big.Float).SetInt(got).Float64()
+		if math.Abs(value-want)/want > 1e-9 {
+			t.Errorf("tick %d: got %s, want about %g", tick, got, want)
+		}
+	}
+}
+
+func TestTickToSqrtPriceX96OutOfRange(t *testing.T) {
+	for _, tick := range []int32{MinTick - 1, MaxTick + 1} {
+		if _, err := TickToSqrtPriceX96(tick); err != ErrTickOutOfRange {
+			t.Errorf("tick %d: got %v, want ErrTickOutOfRange", tick, err)
+		}
+	}
+}
+
+func TestTickToPrice(t *testing.T) {
+	// A pool of WETH (18 decimals) against USDC (6 decimals) at tick -200000 prices WETH at about 2061 USDC
+	price, err := TickToPrice(-200000, 18, 6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	value, _ := price.Float64()
+	if want := math.Pow(1.0001, -200000) * 1e12; math.Abs(value-want)/want > 1e-9 {
+		t.Fatalf("got %g, want about %g", value, want)
+	}
+}
+
+func TestTimeWeightedAverageTick(t *testing.T) {
+	tests := []struct {
+		start, end int64
+		window     uint32
+		want       int32
+	}{
+		{start: 0, end: 360000, window: 3600, want: 100},
+		{start: 1000, end: 1000, window: 60, want: 0},
+		{start: 0, end: 5, window: 2, want: 2},
+		// Rounded towards negative infinity like OracleLibrary.consult
+		{start: 0, end: -7, window: 2, want: -4},
+		{start: 0, end: -6, window: 2, want: -3},
+		{start: 10, end: -1, window: 11, want: -1},
+		{start: -1887272 * 1800, end: -1887272*1800 - 887272*1800, window: 1800, want: -887272},
+	}
+
+	for _, test := range tests {
+		got := TimeWeightedAverageTick(big.NewInt(test.start), big.NewInt(test.end), test.window)
+		if got != test.want {
+			t.Errorf("%d to %d over %d: got %d, want %d", test.start, test.end, test.window, got, test.want)
+		}
+	}
+}
+
+func TestTickBitmap(t *testing.T) {
+	tests := []struct {
+		tick, spacing int32
+		compressed    int32
+		word          int16
+	}{
+		{tick: 0, spacing: 60, compressed: 0, word: 0},
+		{tick: 59, spacing: 60, compressed: 0, word: 0},
+		{tick: -1, spacing: 60, compressed: -1, word: -1},
+		{tick: -60, spacing: 60, compressed: -1, word: -1},
+		{tick: -61, spacing: 60, compressed: -2, word: -1},
+		{tick: 256 * 10, spacing: 10, compressed: 256, word: 1},
+		{tick: MinTick, spacing: 1, compressed: MinTick, word: -3466},
+	}
+
+	for _, test := range tests {
+		if got := compressTick(test.tick, test.spacing); got != test.compressed {
+			t.Errorf("tick %d spacing %d: got compressed %d, want %d", test.tick, test.spacing, got, test.compressed)
+		}
+		if got := tickWord(test.tick, test.spacing); got != test.word {
+			t.Errorf("tick %d spacing %d: got word %d, want %d", test.tick, test.spacing, got, test.word)
+		}
+	}
+
+	word := new(big.Int).SetBit(new(big.Int), 0, 1)
+	word.SetBit(word, 255, 1)
+	ticks := initializedTicks(-1, word, 60)
+	if len(ticks) != 2 || ticks[0] != -256*60 || ticks[1] != -60 {
+		t.Fatalf("got %v", ticks)
+	}
+}
@@ -0,0 +1,289 @@
+// Package uniswapv3 reads Uniswap V3 pool state, ticks and oracle observations through an EthMultiCaller.
+package uniswapv3
+
+import (
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	multicall "github.com/truongpx396/go-eth-multicall"
+	"github.com/truongpx396/go-eth-multicall/contracts/IERC20"
+	"github.com/truongpx396/go-eth-multicall/contracts/IUniswapV3Pool"
+)
+
+var (
+	poolAbi  = multicall.MustParseABI(IUniswapV3Pool.IUniswapV3PoolABI)
+	erc20Abi = multicall.MustParseABI(IERC20.IERC20ABI)
+)
+
+// Options controls how much of the pools is read
+type Options struct {
+	// TickWords is the number of tick bitmap words read on each side of the word holding the current tick.
+	// Each word covers 256 * tickSpacing ticks. Zero only reads the current word, negative skips ticks entirely
+	TickWords int
+	// TWAPWindow is the window in seconds of the time weighted average tick, zero skips the oracle
+	TWAPWindow uint32
+}
+
+// Tick is an initialized tick of a pool
+type Tick struct {
+	Index          int32
+	LiquidityGross *big.Int
+	LiquidityNet   *big.Int
+}
+
+// Pool is the decoded state of a pool
+type Pool struct {
+	Address     common.Address
+	Token0      common.Address
+	Token1      common.Address
+	Decimals0   uint8
+	Decimals1   uint8
+	Fee         uint32
+	TickSpacing int32
+
+	SqrtPriceX96           *big.Int
+	Tick                   int32
+	ObservationIndex       uint16
+	ObservationCardinality uint16
+	Liquidity              *big.Int
+
+	// TickBitmap holds the bitmap words that were read, by word position
+	TickBitmap map[int16]*big.Int
+	// Ticks are the initialized ticks found in TickBitmap, in ascending order
+	Ticks []Tick
+
+	// TWAPTick is the time weighted average tick over TWAPWindow seconds, only valid when TWAPErr is nil
+	TWAPTick   int32
+	TWAPWindow uint32
+	// TWAPErr is set when the oracle could not be observed, typically because the window reaches
+	// further back than the oldest observation
+	TWAPErr error
+
+	// Err is set when the pool did not answer the pool calls, in which case the other fields are incomplete
+	Err error
+}
+
+// Snapshot is the state of all requested pools, read at a single block
+type Snapshot struct {
+	BlockNumber uint64
+	Pools       []Pool
+}
+
+// Price returns the spot price of token0 in token1 in whole token units
+func (pool Pool) Price() *big.Rat {
+	return SqrtPriceX96ToPrice(pool.SqrtPriceX96, pool.Decimals0, pool.Decimals1)
+}
+
+// TWAPPrice returns the time weighted average price of token0 in token1 in whole token units
+func (pool Pool) TWAPPrice() (*big.Rat, error) {
+	if pool.TWAPErr != nil {
+		return nil, pool.TWAPErr
+	}
+
+	return TickToPrice(pool.TWAPTick, pool.Decimals0, pool.Decimals1)
+}
+
+func observeWindow(window uint32) []uint32 {
+	return []uint32{window, 0}
+}
+
+// ReadPools reads the state of every pool.
+//
+// The first multicall reads slot0, liquidity, fee, tick spacing, tokens and the oracle. The second one,
+// pinned to the block of the first, reads token decimals and the tick bitmap words around the current
+// tick, and the third one the initialized ticks found in those words. An error is returned when the block
+// number call of the first multicall failed.
+func ReadPools(caller *multicall.EthMultiCaller, pools []common.Address, options Options) (Snapshot, error) {
+	const blockNumberName = "blockNumber"
+
+	calls := []multicall.Call{caller.GetBlockNumberCall(blockNumberName)}
+	for _, pool := range pools {
+		calls = append(calls,
+			multicall.NewCall(poolAbi, pool, "slot0"),
+			multicall.NewCall(poolAbi, pool, "liquidity"),
+			multicall.NewCall(poolAbi, pool, "fee"),
+			multicall.NewCall(poolAbi, pool, "tickSpacing"),
+			multicall.NewCall(poolAbi, pool, "token0"),
+			multicall.NewCall(poolAbi, pool, "token1"),
+		)
+		if options.TWAPWindow > 0 {
+			calls = append(calls, multicall.NewCall(poolAbi, pool, "observe", observeWindow(options.TWAPWindow)))
+		}
+	}
+
+	responses := caller.Execute(calls)
+
+	blockNumber, err := multicall.UnpackBlockNumber(responses, blockNumberName)
+	if err != nil {
+		return Snapshot{}, err
+	}
+	snapshot := Snapshot{BlockNumber: blockNumber.Uint64(), Pools: make([]Pool, len(pools))}
+
+	var dependent []multicall.Call
+	for i, address := range pools {
+		pool := &snapshot.Pools[i]
+		pool.Address = address
+		if pool.Err = decodeState(pool, responses); pool.Err != nil {
+			continue
+		}
+
+		if options.TWAPWindow > 0 {
+			pool.TWAPWindow = options.TWAPWindow
+			if observed, err := multicall.Unpack(poolAbi, responses, address, "observe", observeWindow(options.TWAPWindow)); err == nil {
+				tickCumulatives := observed[0].([]*big.Int)
+				pool.TWAPTick = TimeWeightedAverageTick(tickCumulatives[0], tickCumulatives[1], options.TWAPWindow)
+			} else {
+				pool.TWAPErr = err
+			}
+		}
+
+		dependent = append(dependent,
+			multicall.NewCall(erc20Abi, pool.Token0, "decimals"),
+			multicall.NewCall(erc20Abi, pool.Token1, "decimals"),
+		)
+		for _, position := range bitmapWords(pool, options) {
+			dependent = append(dependent, multicall.NewCall(poolAbi, address, "tickBitmap", position))
+		}
+	}
+
+	if len(dependent) == 0 {
+		return snapshot, nil
+	}
+
+	responses = caller.ExecuteAtBlock(dependent, blockNumber)
+
+	var tickCalls []multicall.Call
+	for i := range snapshot.Pools {
+		pool := &snapshot.Pools[i]
+		if pool.Err != nil {
+			continue
+		}
+
+		if pool.Err = decodeDecimals(pool, responses); pool.Err != nil {
+			continue
+		}
+
+		pool.TickBitmap = make(map[int16]*big.Int)
+		for _, position := range bitmapWords(pool, options) {
+			word, err := multicall.Unpack(poolAbi, responses, pool.Address, "tickBitmap", position)
+			if err != nil {
+				pool.Err = err
+				break
+			}
+
+			pool.TickBitmap[position] = word[0].(*big.Int)
+			for _, tick := range initializedTicks(position, pool.TickBitmap[position], pool.TickSpacing) {
+				tickCalls = append(tickCalls, multicall.NewCall(poolAbi, pool.Address, "ticks", big.NewInt(int64(tick))))
+			}
+		}
+	}
+
+	if len(tickCalls) == 0 {
+		return snapshot, nil
+	}
+
+	responses = caller.ExecuteAtBlock(tickCalls, blockNumber)
+
+	for i := range snapshot.Pools {
+		pool := &snapshot.Pools[i]
+		if pool.Err != nil {
+			continue
+		}
+
+		for position, word := range pool.TickBitmap {
+			for _, index := range initializedTicks(position, word, pool.TickSpacing) {
+				tick, err := multicall.Unpack(poolAbi, responses, pool.Address, "ticks", big.NewInt(int64(index)))
+				if err != nil {
+					pool.Err = err
+					break
+				}
+
+				pool.Ticks = append(pool.Ticks, Tick{
+					Index:          index,
+					LiquidityGross: tick[0].(*big.Int),
+					LiquidityNet:   tick[1].(*big.Int),
+				})
+			}
+		}
+
+		sort.Slice(pool.Ticks, func(a, b int) bool { return pool.Ticks[a].Index < pool.Ticks[b].Index })
+	}
+
+	return snapshot, nil
+}
+
+// bitmapWords returns the positions of the tick bitmap words to read around the current tick
+func bitmapWords(pool *Pool, options Options) []int16 {
+	if options.TickWords < 0 || pool.TickSpacing <= 0 {
+		return nil
+	}
+
+	current := int(tickWord(pool.Tick, pool.TickSpacing))
+	minWord := int(tickWord(MinTick, pool.TickSpacing))
+	maxWord := int(tickWord(MaxTick, pool.TickSpacing))
+
+	var positions []int16
+	for position := current - options.TickWords; position <= current+options.TickWords; position++ {
+		if position >= minWord && position <= maxWord {
+			positions = append(positions, int16(position))
+		}
+	}
+
+	return positions
+}
+
+func decodeState(pool *Pool, responses map[string]multicall.CallResponse) error {
+	slot0, err := multicall.Unpack(poolAbi, responses, pool.Address, "slot0")
+	if err != nil {
+		return err
+	}
+	liquidity, err := multicall.Unpack(poolAbi, responses, pool.Address, "liquidity")
+	if err != nil {
+		return err
+	}
+	fee, err := multicall.Unpack(poolAbi, responses, pool.Address, "fee")
+	if err != nil {
+		return err
+	}
+	tickSpacing, err := multicall.Unpack(poolAbi, responses, pool.Address, "tickSpacing")
+	if err != nil {
+		return err
+	}
+	token0, err := multicall.Unpack(poolAbi, responses, pool.Address, "token0")
+	if err != nil {
+		return err
+	}
+	token1, err := multicall.Unpack(poolAbi, responses, pool.Address, "token1")
+	if err != nil {
+		return err
+	}
+
+	pool.SqrtPriceX96 = slot0[0].(*big.Int)
+	pool.Tick = int32(slot0[1].(*big.Int).Int64())
+	pool.ObservationIndex = slot0[2].(uint16)
+	pool.ObservationCardinality = slot0[3].(uint16)
+	pool.Liquidity = liquidity[0].(*big.Int)
+	pool.Fee = uint32(fee[0].(*big.Int).Uint64())
+	pool.TickSpacing = int32(tickSpacing[0].(*big.Int).Int64())
+	pool.Token0 = token0[0].(common.Address)
+	pool.Token1 = token1[0].(common.Address)
+
+	return nil
+}
+
+func decodeDecimals(pool *Pool, responses map[string]multicall.CallResponse) error {
+	decimals0, err := multicall.Unpack(erc20Abi, responses, pool.Token0, "decimals")
+	if err != nil {
+		return err
+	}
+	decimals1, err := multicall.Unpack(erc20Abi, responses, pool.Token1, "decimals")
+	if err != nil {
+		return err
+	}
+
+	pool.Decimals0 = decimals0[0].(uint8)
+	pool.Decimals1 = decimals1[0].(uint8)
+
+	return nil
+}
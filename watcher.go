@@ -0,0 +1,169 @@
+package go_eth_multicall
+
+import (
+	"context"
+	"math/big"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+)
+
+// DefaultPollInterval is how often a watcher asks for the latest header when the connection does not support
+// subscriptions
+const DefaultPollInterval = 2 * time.Second
+
+// WatchOptions configures a watcher
+type WatchOptions struct {
+	// SkipBlocks makes the watcher jump to the newest head when it falls behind, because executions are slow or
+	// updates are not consumed fast enough. Without it every block is executed in order.
+	SkipBlocks bool
+	// PollInterval is used over connections without subscription support, DefaultPollInterval when zero
+	PollInterval time.Duration
+	// Buffer is the capacity of the update channel
+	Buffer int
+}
+
+// Update is the outcome of executing the watched calls at a new block. Err is set when the execution failed,
+// in which case the ResultSet only identifies the block.
+type Update struct {
+	ResultSet
+	Err error
+}
+
+// Watch executes the calls at every new block until ctx is done, emitting the results on the returned channel.
+//
+// New heads come from SubscribeNewHead when the client is connected over websockets or IPC, and from polling the
+// latest header otherwise. Each execution is pinned to the hash of its head. The channel is closed once ctx is done.
+func (caller *EthMultiCaller) Watch(ctx context.Context, calls []Call, options WatchOptions) <-chan Update {
+	if options.PollInterval <= 0 {
+		options.PollInterval = DefaultPollInterval
+	}
+
+	headBuffer := 64
+	if options.SkipBlocks {
+		headBuffer = 1
+	}
+
+	heads := make(chan *blockHeader, headBuffer)
+	updates := make(chan Update, options.Buffer)
+
+	go caller.followHeads(ctx, heads, options)
+	go func() {
+		defer close(updates)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case header := <-heads:
+				update := Update{ResultSet: newResultSet(header, calls)}
+				update.Assurance = AssuranceBlockHash
+				executeCtx, retries := countRetries(ctx)
+				update.Responses, update.Err = caller.ExecuteAtHash(executeCtx, calls, update.BlockHash)
+				update.Retries = int(atomic.LoadInt64(retries))
+				if update.Err != nil && ctx.Err() != nil {
+					return
+				}
+
+				select {
+				case updates <- update:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return updates
+}
+
+// offerHead queues a head for execution. When skipping blocks a head that was not picked up yet is replaced.
+func offerHead(ctx context.Context, heads chan *blockHeader, header *blockHeader, skip bool) {
+	if !skip {
+		select {
+		case heads <- header:
+		case <-ctx.Done():
+		}
+		return
+	}
+
+	for {
+		select {
+		case heads <- header:
+			return
+		default:
+			select {
+			case <-heads:
+			default:
+			}
+		}
+	}
+}
+
+// followHeads feeds the new heads of the chain into heads until ctx is done
+func (caller *EthMultiCaller) followHeads(ctx context.Context, heads chan *blockHeader, options WatchOptions) {
+	subscribed := make(chan *blockHeader)
+	err := caller.limiter(ctx).Wait(ctx, "eth_subscribe", 1)
+	var subscription ethereum.Subscription
+	if err == nil {
+		subscription, err = caller.subscribeNewHead(ctx, subscribed)
+	}
+	if err == nil {
+		defer subscription.Unsubscribe()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case header := <-subscribed:
+				offerHead(ctx, heads, header, options.SkipBlocks)
+			case err := <-subscription.Err():
+				if err == nil {
+					return
+				}
+				// The connection dropped, keep going by polling
+				caller.pollHeads(ctx, heads, options)
+				return
+			}
+		}
+	}
+	if ctx.Err() != nil {
+		return
+	}
+
+	// HTTP connections do not support subscriptions, anything else failing to subscribe gets the same treatment
+	caller.pollHeads(ctx, heads, options)
+}
+
+// pollHeads polls the latest header and feeds the new ones into heads until ctx is done. Unless blocks are skipped,
+// the headers of blocks mined between two polls are fetched as well.
+func (caller *EthMultiCaller) pollHeads(ctx context.Context, heads chan *blockHeader, options WatchOptions) {
+	ticker := time.NewTicker(options.PollInterval)
+	defer ticker.Stop()
+
+	var last *big.Int
+	for {
+		header, err := caller.headerByNumber(ctx, nil)
+		if err == nil && (last == nil || header.Number.Cmp(last) > 0) {
+			if last != nil && !options.SkipBlocks {
+				for number := new(big.Int).Add(last, big.NewInt(1)); number.Cmp(header.Number) < 0; number.Add(number, big.NewInt(1)) {
+					missed, err := caller.headerByNumber(ctx, number)
+					if err != nil {
+						break
+					}
+					offerHead(ctx, heads, missed, false)
+				}
+			}
+
+			last = header.Number
+			offerHead(ctx, heads, header, options.SkipBlocks)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}